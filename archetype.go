@@ -18,8 +18,8 @@ func (a Archetype) String() string {
 
 	text += "\nSources: "
 
-	for _, s := range a.Sources {
-		text += fmt.Sprintf("%s (%dpts), ", s.Type, s.Cost)
+	for i, s := range a.Sources {
+		text += fmt.Sprintf("%s (%dpts), ", s.Type, a.sourceCost(i))
 	}
 
 	text = text[:len(text)-2]
@@ -45,30 +45,23 @@ func (a Archetype) String() string {
 }
 
 // CalculateCost adds costs from sources, permissions and intrinsics
-func (a *Archetype) CalculateCost() {
-	var c int
-
-	for _, s := range a.Sources {
-		//First Source is free and all sources but focus cost 5pts
-		if s.Type == a.Sources[0].Type && s.Cost > 0 {
-			s.Cost = 0
-		}
-		c += s.Cost
-	}
+func (a *Archetype) CalculateCost() int {
+	a.Cost = a.Price()
+	return a.Cost
+}
 
-	for _, p := range a.Permissions {
-		c += p.Cost
-	}
+// Price returns the Archetype's cost without changing it
+func (a *Archetype) Price() int {
+	return a.ExplainCost().Cost
+}
 
-	for _, i := range a.Intrinsics {
-		if i.RequiresLevel {
-			c += i.Cost * i.Level
-		} else {
-			c += i.Cost
-		}
+// sourceCost prices the ith Source.
+// First Source is free and all sources but focus cost 5pts
+func (a *Archetype) sourceCost(i int) int {
+	if a.Sources[i].Type == a.Sources[0].Type {
+		return 0
 	}
-
-	a.Cost = c
+	return a.Sources[i].Cost
 }
 
 // Source is a source of a Character's powers
@@ -276,7 +269,7 @@ var Intrinsics = map[string]Intrinsic{
 	},
 	"Unhealing": Intrinsic{
 		Name:        "Unhealing",
-		Description: "",
+		Description: "Wounds never heal naturally and must be treated",
 		Cost:        -8,
 	},
 	"Vulnerable": Intrinsic{
@@ -0,0 +1,206 @@
+package oneroll
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// TargetReport groups every hit on one target of an attack
+type TargetReport struct {
+	Target *Character
+	Hits   []*WoundReport
+}
+
+// AttackReport is the outcome of an attack against one or more targets
+type AttackReport struct {
+	Roll    *Roll
+	Targets []*TargetReport
+}
+
+// target returns the report for c, adding it if needed
+func (ar *AttackReport) target(c *Character) *TargetReport {
+
+	for _, tr := range ar.Targets {
+		if tr.Target == c {
+			return tr
+		}
+	}
+
+	tr := &TargetReport{Target: c}
+	ar.Targets = append(ar.Targets, tr)
+	return tr
+}
+
+// SprayAttack lets each match of a spray attack strike a different target.
+// Matches are dealt widest first to targets in the order given, starting
+// again with the first target if there are more matches than targets.
+func SprayAttack(r *Roll, spec DamageSpec, targets ...*Character) (*AttackReport, error) {
+
+	if len(targets) == 0 {
+		return nil, errors.New("spray attack has no targets")
+	}
+
+	ar := &AttackReport{Roll: r}
+
+	for _, t := range targets {
+		ar.target(t)
+	}
+
+	matches := append([]Match{}, r.Matches...)
+	sort.SliceStable(matches, func(i, j int) bool {
+		a, b := matches[i], matches[j]
+		return a.Width > b.Width || (a.Width == b.Width && a.Height > b.Height)
+	})
+
+	for i, m := range matches {
+		t := targets[i%len(targets)]
+
+		wr, err := t.ApplyHit(m, spec)
+		if err != nil {
+			return nil, err
+		}
+		ar.target(t).Hits = append(ar.target(t).Hits, wr)
+	}
+
+	return ar, nil
+}
+
+// AreaAttack strikes target with the best match of r, then rolls spec.Area
+// area dice against target and each Character in range. Every area die
+// deals one point at the location it shows: Killing if spec deals any
+// Killing, otherwise Shock. HAR left after penetration stops an area die,
+// and LAR absorbs Shock. An attack without a match does nothing.
+func AreaAttack(r *Roll, spec DamageSpec, target *Character, inRange ...*Character) (*AttackReport, error) {
+
+	ar := &AttackReport{Roll: r}
+
+	m, ok := bestMatch(r)
+	if !ok {
+		return ar, nil
+	}
+
+	var victims []*Character
+
+	if target != nil {
+		wr, err := target.ApplyHit(m, spec)
+		if err != nil {
+			return nil, err
+		}
+		ar.target(target).Hits = append(ar.target(target).Hits, wr)
+		victims = append(victims, target)
+	}
+
+	victims = append(victims, inRange...)
+
+	killing := spec.Evaluate(Match{Width: 1}).Killing > 0
+
+	for _, c := range victims {
+		tr := ar.target(c)
+
+		for i := 0; i < spec.Area; i++ {
+			face := RollDieFrom(r.source(), 10, 1, 1)
+
+			wr, err := c.areaHit(face, killing, spec.Penetration)
+			if err != nil {
+				return nil, err
+			}
+			tr.Hits = append(tr.Hits, wr)
+		}
+	}
+
+	return ar, nil
+}
+
+// Bystander is a Character near the target of an attack
+type Bystander struct {
+	Character *Character
+	Distance  int // Metres from the target
+}
+
+// QualityAttack resolves r as an attack made with the Attack Quality q of a
+// Power. The area dice come from q's Area modifier, replacing any in spec,
+// and only bystanders within q's Radius are rolled against. A Quality
+// without Area strikes target alone.
+func QualityAttack(r *Roll, q *Quality, spec DamageSpec, target *Character, nearby ...Bystander) (*AttackReport, error) {
+
+	if q == nil || q.Type != "Attack" {
+		return nil, errors.New("quality attack needs an Attack Quality")
+	}
+
+	spec.Area = q.AreaDice()
+
+	var inRange []*Character
+
+	if spec.Area > 0 {
+		radius := q.Radius()
+		for _, b := range nearby {
+			if b.Character != nil && b.Character != target && b.Distance <= radius {
+				inRange = append(inRange, b.Character)
+			}
+		}
+	}
+
+	return AreaAttack(r, spec, target, inRange...)
+}
+
+// areaHit applies a single area die showing face
+func (c *Character) areaHit(face int, killing bool, penetration int) (*WoundReport, error) {
+
+	loc := c.LocationAt(face)
+	if loc == nil {
+		return nil, fmt.Errorf("%s has no hit location at height %d", c.Name, face)
+	}
+
+	m := Match{Height: face, Width: 1}
+
+	har := Max(loc.HAR-penetration, 0)
+	lar := Max(loc.LAR-Max(penetration-loc.HAR, 0), 0)
+
+	if har > 0 {
+		return &WoundReport{Target: c, Match: m, HAR: har, LAR: lar, Blocked: true}, nil
+	}
+
+	d := Damage{Shock: 1}
+	if killing {
+		d = Damage{Killing: 1}
+	}
+
+	full := d
+	d.Shock = Max(d.Shock-lar, 0)
+
+	wr := c.ApplyWounds(loc, d)
+	wr.Match = m
+	wr.HAR = har
+	wr.LAR = lar
+	wr.Absorbed = Damage{Shock: full.Shock - d.Shock}
+
+	return wr, nil
+}
+
+func (ar AttackReport) String() string {
+
+	text := ""
+
+	if ar.Roll != nil && ar.Roll.Actor != nil {
+		text += fmt.Sprintf("***Attack by %s***\n", ar.Roll.Actor.Name)
+	}
+
+	if len(ar.Targets) == 0 {
+		return text + "The attack misses\n"
+	}
+
+	for _, tr := range ar.Targets {
+		text += fmt.Sprintf("\n%s:\n", tr.Target.Name)
+
+		if len(tr.Hits) == 0 {
+			text += "Not hit\n"
+		}
+
+		for _, wr := range tr.Hits {
+			text += fmt.Sprintf("%dx%d: %s", wr.Match.Width, wr.Match.Height, wr)
+		}
+	}
+
+	return text
+}
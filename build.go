@@ -0,0 +1,344 @@
+package oneroll
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// BuildRules are a setting's defaults for point-budget character building
+type BuildRules struct {
+	Budget int            // Total points, 0 for no budget
+	Caps   map[string]int // Most points per DetailedCost category
+}
+
+// Builds holds the BuildRules for each setting code
+var Builds = map[string]BuildRules{
+	"WT": BuildRules{
+		Budget: 250,
+		Caps:   map[string]int{},
+	},
+	"SR": BuildRules{
+		Budget: 250,
+		Caps:   map[string]int{},
+	},
+}
+
+// Purchase is one change made during a BuildSession
+type Purchase struct {
+	Item  string
+	Cost  int // Points spent, negative for a refund
+	Total int // Points spent on the Character afterwards
+}
+
+// BudgetError explains why a purchase was refused
+type BudgetError struct {
+	Item     string
+	Category string // DetailedCost category over its cap, empty for the budget
+	Cost     int    // Points the Character or category would cost
+	Limit    int
+}
+
+func (e *BudgetError) Error() string {
+	if e.Category != "" {
+		return fmt.Sprintf("%s would raise %s to %dpts, over the cap of %dpts",
+			e.Item, e.Category, e.Cost, e.Limit)
+	}
+	return fmt.Sprintf("%s would cost %dpts in total, %dpts over the budget of %dpts",
+		e.Item, e.Cost, e.Cost-e.Limit, e.Limit)
+}
+
+// BuildSession builds a Character to a point budget. Every change goes
+// through the session, which recalculates the cost and undoes any change
+// that takes the Character over the Budget or a category over its cap.
+// Changes that lower the cost are always allowed. Base Will and
+// Willpower follow the Statistics until they are bought with SetWill.
+type BuildSession struct {
+	Character *Character
+	Budget    int
+	Caps      map[string]int
+	History   []Purchase
+
+	willBought bool
+}
+
+// NewBuildSession starts building c to budget, or to the default budget
+// for c's Setting if budget is 0. Caps are copied from the setting's
+// BuildRules and may be changed.
+func NewBuildSession(c *Character, budget int) (*BuildSession, error) {
+
+	if c == nil {
+		return nil, errors.New("no character to build")
+	}
+
+	if c.InPlay {
+		return nil, fmt.Errorf("%s is in play and cannot be built", c.Name)
+	}
+
+	rules := Builds[c.Setting]
+
+	if budget == 0 {
+		budget = rules.Budget
+	}
+
+	b := &BuildSession{
+		Character: c,
+		Budget:    budget,
+		Caps:      map[string]int{},
+	}
+
+	for k, v := range rules.Caps {
+		b.Caps[k] = v
+	}
+
+	// Will that differs from the Statistics was bought before the session
+	b.willBought = c.BaseWill != 0 &&
+		(c.BaseWill != c.calcBaseWill() || c.Willpower != c.BaseWill)
+
+	b.cost()
+
+	return b, nil
+}
+
+// Spent returns the points spent on the Character
+func (b *BuildSession) Spent() int {
+	return b.cost()
+}
+
+// cost recalculates the Character's cost, first clearing Base Will and
+// Willpower so CalculateCost sets them from the Statistics again
+func (b *BuildSession) cost() int {
+
+	c := b.Character
+
+	if !b.willBought && c.Setting != "RE" {
+		c.BaseWill, c.Willpower = 0, 0
+	}
+	return c.CalculateCost()
+}
+
+// Remaining returns the points left in the Budget
+func (b *BuildSession) Remaining() int {
+	return b.Budget - b.Spent()
+}
+
+// try applies a change, keeping it only if the Character stays within
+// the Budget and Caps. undo must restore the Character exactly.
+func (b *BuildSession) try(item string, apply, undo func()) error {
+
+	c := b.Character
+
+	before := b.cost()
+	was := map[string]int{}
+	for k, v := range c.DetailedCost {
+		was[k] = v
+	}
+
+	apply()
+
+	after := b.cost()
+
+	var err *BudgetError
+
+	if b.Budget > 0 && after > b.Budget && after > before {
+		err = &BudgetError{Item: item, Cost: after, Limit: b.Budget}
+	}
+
+	if err == nil {
+		for _, cat := range sortedCaps(b.Caps) {
+			cost, limit := c.DetailedCost[cat], b.Caps[cat]
+			if limit > 0 && cost > limit && cost > was[cat] {
+				err = &BudgetError{Item: item, Category: cat, Cost: cost, Limit: limit}
+				break
+			}
+		}
+	}
+
+	if err != nil {
+		undo()
+		b.cost()
+		return err
+	}
+
+	b.History = append(b.History, Purchase{
+		Item:  item,
+		Cost:  after - before,
+		Total: after,
+	})
+	return nil
+}
+
+// SetStat sets the dice of the named Statistic
+func (b *BuildSession) SetStat(name string, d *DiePool) error {
+
+	s := b.Character.Statistics[name]
+	if s == nil {
+		return fmt.Errorf("%s has no Statistic %q", b.Character.Name, name)
+	}
+
+	old := s.Dice
+	return b.try(fmt.Sprintf("%s %s", name, d),
+		func() { s.Dice = d.clone() },
+		func() { s.Dice = old })
+}
+
+// SetSkill sets the dice of the named Skill
+func (b *BuildSession) SetSkill(name string, d *DiePool) error {
+
+	s := b.Character.Skills[name]
+	if s == nil {
+		return fmt.Errorf("%s has no Skill %q", b.Character.Name, name)
+	}
+
+	old := s.Dice
+	return b.try(fmt.Sprintf("%s %s", name, d),
+		func() { s.Dice = d.clone() },
+		func() { s.Dice = old })
+}
+
+// SetHyperStat adds a HyperStat to the named Statistic, or removes it if hs is nil
+func (b *BuildSession) SetHyperStat(name string, hs *HyperStat) error {
+
+	s := b.Character.Statistics[name]
+	if s == nil {
+		return fmt.Errorf("%s has no Statistic %q", b.Character.Name, name)
+	}
+
+	item := fmt.Sprintf("remove %s HyperStat", name)
+	if hs != nil {
+		item = fmt.Sprintf("%s %s", hs.Name, hs.Dice)
+	}
+
+	old := s.HyperStat
+	return b.try(item,
+		func() { s.HyperStat = hs },
+		func() { s.HyperStat = old })
+}
+
+// SetHyperSkill adds a HyperSkill to the named Skill, or removes it if hs is nil
+func (b *BuildSession) SetHyperSkill(name string, hs *HyperSkill) error {
+
+	s := b.Character.Skills[name]
+	if s == nil {
+		return fmt.Errorf("%s has no Skill %q", b.Character.Name, name)
+	}
+
+	item := fmt.Sprintf("remove %s HyperSkill", name)
+	if hs != nil {
+		item = fmt.Sprintf("%s %s", hs.Name, hs.Dice)
+	}
+
+	old := s.HyperSkill
+	return b.try(item,
+		func() { s.HyperSkill = hs },
+		func() { s.HyperSkill = old })
+}
+
+// AddPower adds or replaces a Power
+func (b *BuildSession) AddPower(p *Power) error {
+
+	c := b.Character
+
+	old, had := c.Powers[p.Name]
+	return b.try(fmt.Sprintf("%s %s", p.Name, p.Dice),
+		func() {
+			if c.Powers == nil {
+				c.Powers = map[string]*Power{}
+			}
+			c.Powers[p.Name] = p
+		},
+		func() {
+			if had {
+				c.Powers[p.Name] = old
+			} else {
+				delete(c.Powers, p.Name)
+			}
+		})
+}
+
+// RemovePower removes the named Power
+func (b *BuildSession) RemovePower(name string) error {
+
+	c := b.Character
+
+	old, had := c.Powers[name]
+	if !had {
+		return fmt.Errorf("%s has no Power %q", c.Name, name)
+	}
+
+	return b.try("remove "+name,
+		func() { delete(c.Powers, name) },
+		func() { c.Powers[name] = old })
+}
+
+// AddAdvantage adds an Advantage
+func (b *BuildSession) AddAdvantage(a *Advantage) error {
+
+	c := b.Character
+
+	old := c.Advantages
+	return b.try(a.Name,
+		func() { c.Advantages = append(append([]*Advantage{}, old...), a) },
+		func() { c.Advantages = old })
+}
+
+// SetArchetype replaces the Character's Archetype
+func (b *BuildSession) SetArchetype(a *Archetype) error {
+
+	c := b.Character
+
+	old := c.Archetype
+	return b.try("Archetype "+a.Type,
+		func() { c.Archetype = a },
+		func() { c.Archetype = old })
+}
+
+// SetWill buys Base Will and Willpower
+func (b *BuildSession) SetWill(baseWill, willpower int) error {
+
+	c := b.Character
+
+	oldBase, oldWill, oldBought := c.BaseWill, c.Willpower, b.willBought
+	return b.try(fmt.Sprintf("Base Will %d, Willpower %d", baseWill, willpower),
+		func() { c.BaseWill, c.Willpower, b.willBought = baseWill, willpower, true },
+		func() { c.BaseWill, c.Willpower, b.willBought = oldBase, oldWill, oldBought })
+}
+
+func (b BuildSession) String() string {
+
+	c := b.Character
+
+	text := fmt.Sprintf("Building %s: %dpts spent", c.Name, b.Spent())
+
+	if b.Budget > 0 {
+		text += fmt.Sprintf(" of %dpts, %dpts remaining", b.Budget, b.Remaining())
+	}
+	text += "\n"
+
+	var cats []string
+	for k := range c.DetailedCost {
+		cats = append(cats, k)
+	}
+	sort.Strings(cats)
+
+	for _, k := range cats {
+		text += fmt.Sprintf("%s: %dpts", k, c.DetailedCost[k])
+		if limit := b.Caps[k]; limit > 0 {
+			text += fmt.Sprintf(" (cap %dpts)", limit)
+		}
+		text += "\n"
+	}
+
+	return text
+}
+
+// sortedCaps lists capped categories in a stable order
+func sortedCaps(caps map[string]int) []string {
+
+	var keys []string
+	for k := range caps {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -22,6 +22,7 @@ type Character struct {
 	Permissions  map[string]*Permission
 	Powers       map[string]*Power
 	Gear         string
+	Armor        []*Armor
 	HitLocations map[string]*Location
 	Passions     []*Passion
 	Advantages   []*Advantage
@@ -66,6 +67,10 @@ func (c *Character) String() string {
 
 	text += fmt.Sprintf("\nHit Locations:\n")
 
+	if st := c.Status(); !st.Healthy() {
+		text += fmt.Sprintf("Status: %s\n", st)
+	}
+
 	for _, loc := range c.LocationMap {
 		text += fmt.Sprintf("%s\n", c.HitLocations[loc])
 	}
@@ -90,8 +95,8 @@ func (c *Character) String() string {
 					cost := 0
 					text += fmt.Sprintf("+ added modifiers to main stat: ")
 					for _, m := range s.Modifiers {
-						text += fmt.Sprintf("%s (%d/die) (%dpts), ", m.Name, m.Cost, m.Cost*SumDice(s.Dice))
-						cost += m.Cost * SumDice(s.Dice)
+						text += fmt.Sprintf("%s (%d/die) (%dpts), ", m.Name, m.Price(), m.Price()*SumDice(s.Dice))
+						cost += m.Price() * SumDice(s.Dice)
 					}
 					text = strings.TrimSuffix(text, ",")
 					text += fmt.Sprintf("(%dpts)", cost)
@@ -116,8 +121,8 @@ func (c *Character) String() string {
 					cost := 0
 					text += fmt.Sprintf("+ added modifiers to main skill: ")
 					for _, m := range s.Modifiers {
-						text += fmt.Sprintf("%s (%d/die) (%dpts), ", m.Name, m.Cost, m.Cost*SumDice(s.Dice))
-						cost += m.Cost * SumDice(s.Dice)
+						text += fmt.Sprintf("%s (%d/die) (%dpts), ", m.Name, m.Price(), m.Price()*SumDice(s.Dice))
+						cost += m.Price() * SumDice(s.Dice)
 					}
 					text = strings.TrimSuffix(text, ",")
 					text += fmt.Sprintf("(%dpts)", cost)
@@ -141,93 +146,135 @@ func (c *Character) String() string {
 	return text
 }
 
+// RollAbility rolls one or more of the Character's abilities joined by +,
+// such as "Body+Athletics", "Hyper-Body" or a Power name. Skills rolled
+// without any Statistic have their linked Statistics added automatically;
+// naming a Statistic, even a different one, rolls only what was named.
+func (c *Character) RollAbility(name string, opts RollOptions) (*Roll, error) {
+
+	var dice []Dice
+	var names []string
+	var skills []*Skill
+
+	stats := map[*Statistic]bool{}
+
+	for _, part := range strings.Split(name, "+") {
+		n := strings.TrimSpace(part)
+
+		d, err := c.findAbility(n)
+		if err != nil {
+			return nil, err
+		}
+
+		switch a := d.(type) {
+		case *Statistic:
+			stats[a] = true
+		case *Skill:
+			skills = append(skills, a)
+		}
+
+		dice = append(dice, d)
+		names = append(names, n)
+	}
+
+	named := len(stats) > 0
+
+	for _, s := range skills {
+		if !named && s.LinkStat != nil && !stats[s.LinkStat] {
+			stats[s.LinkStat] = true
+			dice = append(dice, s.LinkStat)
+		}
+	}
+
+	r := &Roll{
+		Actor:  c,
+		Action: strings.Join(names, "+"),
+	}
+
+	return r.ResolvePool(CombineDice(dice...), opts)
+}
+
+// findAbility looks up a Statistic, Skill, HyperStat, HyperSkill or Power by name
+func (c *Character) findAbility(name string) (Dice, error) {
+
+	for k, s := range c.Statistics {
+		if strings.EqualFold(k, name) ||
+			(s.HyperStat != nil && strings.EqualFold(s.HyperStat.Name, name)) {
+			return s, nil
+		}
+	}
+
+	for k, s := range c.Skills {
+		if strings.EqualFold(k, name) ||
+			(s.HyperSkill != nil && strings.EqualFold(s.HyperSkill.Name, name)) {
+			return s, nil
+		}
+	}
+
+	for k, p := range c.Powers {
+		if strings.EqualFold(k, name) {
+			return p, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%s has no ability named %q", c.Name, name)
+}
+
 // CalculateCost updates the character and sums
-// total costs of all character elements. Call this on each character update
-func (c *Character) CalculateCost() {
+// total costs of all character elements. Call this on each character update;
+// it never changes the dice, Qualities or Modifiers being priced.
+func (c *Character) CalculateCost() int {
 
 	if !c.InPlay {
 
-		var statsCost, skillsCost, powerCost int
-		var archetypeCost, baseWillCost, willpowerCost int
-		var advantageCost int
+		cf := c.CostFramework()
 
-		if c.Setting != "RE" {
-			if len(c.Archetype.Sources) > 0 {
-				UpdateCost(c.Archetype)
-				archetypeCost += c.Archetype.Cost
-			}
+		if c.Setting != "RE" && c.Archetype != nil && len(c.Archetype.Sources) > 0 {
+			UpdateCost(c.Archetype)
 		}
 
 		for _, stat := range c.Statistics {
-			UpdateCost(stat)
-			statsCost += stat.Cost
+			UpdateCostFor(stat, cf)
 
 			if stat.HyperStat != nil {
-				UpdateCost(stat.HyperStat)
-				powerCost += stat.HyperStat.Cost
+				UpdateCostFor(stat.HyperStat, cf)
 			}
 		}
 
 		for _, skill := range c.Skills {
-			UpdateCost(skill)
-			skillsCost += skill.Cost
+			UpdateCostFor(skill, cf)
 
 			if skill.HyperSkill != nil {
-				UpdateCost(skill.HyperSkill)
-				powerCost += skill.HyperSkill.Cost
+				UpdateCostFor(skill.HyperSkill, cf)
 			}
 		}
 
 		for _, power := range c.Powers {
+			if power.Slug == "" {
+				power.Slug = ToSnakeCase(power.Name)
+			}
 			// Determine power capacities
 			power.DeterminePowerCapacities()
-			UpdateCost(power)
-			powerCost += power.Cost
-		}
-
-		for _, advantage := range c.Advantages {
-			if advantage.RequiresLevel {
-				advantageCost += advantage.Cost * advantage.Level
-			} else {
-				advantageCost += advantage.Cost
-			}
+			UpdateCostFor(power, cf)
 		}
 
 		// Update BaseWill automaticallly if Character isn't in play
+		if c.Setting != "RE" && c.BaseWill == 0 {
+			// Auto-calculate base costs and levels for base character
+			c.BaseWill = c.calcBaseWill()
+			c.Willpower = c.BaseWill
+		}
 
-		calcBaseWill := 0
-
-		if c.Setting != "RE" {
-			for _, stat := range c.Statistics {
-				if stat.EffectsWill {
-					calcBaseWill += SumDice(stat.Dice)
-					if stat.HyperStat != nil {
-						calcBaseWill += SumDice(stat.HyperStat.Dice)
-					}
-				}
-			}
-
-			if c.BaseWill == 0 {
-				// Auto-calculate base costs and levels for base character
-				c.BaseWill = calcBaseWill
-				c.Willpower = c.BaseWill
-			}
+		ci := c.ExplainCost()
 
-			baseWillCost += 3 * (c.BaseWill - calcBaseWill)
-			willpowerCost += c.Willpower - c.BaseWill
+		c.PointCost = ci.Cost
 
-		}
-		c.PointCost = archetypeCost + statsCost + skillsCost + powerCost + advantageCost + willpowerCost + baseWillCost
-
-		c.DetailedCost = map[string]int{
-			"archetype":  archetypeCost,
-			"stats":      statsCost,
-			"skills":     skillsCost,
-			"powers":     powerCost,
-			"advantages": advantageCost,
-			"willpower":  willpowerCost,
-			"basewill":   baseWillCost,
+		c.DetailedCost = map[string]int{}
+		for _, cat := range ci.Items {
+			key := strings.ToLower(strings.Replace(cat.Name, " ", "", -1))
+			c.DetailedCost[key] = cat.Cost
 		}
 	}
 	//What happens when Character is in play
+	return c.PointCost
 }
@@ -0,0 +1,507 @@
+package oneroll
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ActionType is what a declared action tries to do
+type ActionType string
+
+// Action types for combat declarations
+const (
+	Attack ActionType = "attack"
+	Defend ActionType = "defend"
+	Useful ActionType = "useful"
+)
+
+// Action is one of the actions an actor declares for a round
+type Action struct {
+	Type   ActionType
+	Target *Character  // Character attacked, or protected by Defend (nil for self)
+	Effect string      // Description of a Useful action
+	Damage *DamageSpec // Damage dealt by an Attack, Unarmed if nil
+}
+
+// Declaration is everything an actor commits to before the dice are rolled.
+// Each Action is paid for with a die from the pool as in Roll.Resolve.
+type Declaration struct {
+	Actor   *Character
+	Ability string   // Rolled with Character.RollAbility, e.g. "Body+Brawling"
+	Dice    *DiePool // Rolled directly instead of Ability if set
+	Options RollOptions
+	Actions []Action
+}
+
+// Round phases run in order: declare, roll, resolve
+const (
+	phaseDeclare = iota
+	phaseRoll
+	phaseResolve
+)
+
+// Round is a single combat round driven entirely by declared data
+type Round struct {
+	Declarations []*Declaration
+	Rolls        []*Roll
+	Source       RandomSource // nil uses a shared, time-seeded source
+	phase        int
+}
+
+// NewRound starts a round drawing its dice from src
+func NewRound(src RandomSource) *Round {
+	return &Round{Source: src}
+}
+
+// Declare adds an actor's declaration. Declarations close once the round is rolled.
+func (rd *Round) Declare(d Declaration) error {
+
+	if rd.phase != phaseDeclare {
+		return errors.New("declarations are closed for this round")
+	}
+
+	if d.Actor == nil {
+		return errors.New("declaration has no actor")
+	}
+
+	if len(d.Actions) == 0 {
+		return fmt.Errorf("%s declared no actions", d.Actor.Name)
+	}
+
+	if st := d.Actor.Status(); (st.Dead || st.Unconscious) && !d.Options.IgnoreWounds {
+		return fmt.Errorf("%s cannot act: %s", d.Actor.Name, st)
+	}
+
+	for _, a := range d.Actions {
+		switch a.Type {
+		case Attack:
+			if a.Target == nil {
+				return fmt.Errorf("%s declared an attack without a target", d.Actor.Name)
+			}
+		case Defend, Useful:
+		default:
+			return fmt.Errorf("%s declared unknown action %q", d.Actor.Name, a.Type)
+		}
+	}
+
+	rd.Declarations = append(rd.Declarations, &d)
+
+	return nil
+}
+
+// Roll rolls every declaration, one action per die penalty
+func (rd *Round) Roll() error {
+
+	if rd.phase != phaseDeclare {
+		return errors.New("round has already been rolled")
+	}
+
+	// Rolls are kept only once every declaration has rolled, so a
+	// failed Roll can be retried without misaligning Rolls
+	var rolls []*Roll
+
+	for _, d := range rd.Declarations {
+
+		opts := d.Options
+		opts.Actions = len(d.Actions)
+
+		if opts.Source == nil {
+			opts.Source = rd.Source
+		}
+
+		var r *Roll
+		var err error
+
+		if d.Dice != nil {
+			r = &Roll{
+				Actor:  d.Actor,
+				Action: d.Ability,
+			}
+			_, err = r.ResolvePool(d.Dice, opts)
+		} else {
+			r, err = d.Actor.RollAbility(d.Ability, opts)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		rolls = append(rolls, r)
+	}
+
+	rd.Rolls = rolls
+	rd.phase = phaseRoll
+
+	return nil
+}
+
+// combatMatch is a Match allocated to a declared Action during resolution
+type combatMatch struct {
+	Match
+	decl   *Declaration
+	action Action
+	broken bool
+	done   bool
+	spent  int // gobble dice used by a Defend match
+}
+
+// gobbleDice returns the gobble dice a Defend match has left to spend
+func (cm *combatMatch) gobbleDice() int {
+	if cm.action.Type != Defend || cm.broken {
+		return 0
+	}
+	return Max(cm.Width-cm.spent, 0)
+}
+
+// protects reports whether a Defend match guards c
+func (cm *combatMatch) protects(c *Character) bool {
+	if cm.action.Target == nil {
+		return cm.decl.Actor == c
+	}
+	return cm.action.Target == c
+}
+
+// Gobble records gobble dice spent by a defender against an attack
+type Gobble struct {
+	Defender *Character
+	Height   int // Height of the gobble dice
+	Dice     int
+}
+
+// RoundEvent is one thing that happened during resolution
+type RoundEvent struct {
+	Actor    *Character
+	Action   Action
+	Match    Match // Match as it stood when it resolved
+	Target   *Character
+	Location *Location    // Location struck by an attack
+	Wound    *WoundReport // Damage the attack dealt
+	Broken   bool         // Match was knocked out before it could act
+	Gobbled  []Gobble     // Gobble dice spent against an attack
+	Text     string
+}
+
+// RoundReport is the full outcome of a Round
+type RoundReport struct {
+	Rolls  []*Roll
+	Events []RoundEvent
+}
+
+// Resolve allocates each actor's matches to their declared actions,
+// fastest match to first action, then plays them out in initiative order.
+// A Defend match provides gobble dice, one per point of width at its
+// height, that cancel dice from attacks on the defended character of
+// equal or lower height and no greater initiative. An attack that keeps
+// a pair strikes the location its height shows and knocks a die out of
+// the target's widest match that has yet to act, breaking it if that
+// leaves less than a pair. Actions without a match fail.
+func (rd *Round) Resolve() (*RoundReport, error) {
+
+	if rd.phase != phaseRoll {
+		return nil, errors.New("round must be rolled before it is resolved")
+	}
+
+	rep := &RoundReport{Rolls: rd.Rolls}
+
+	var queue []*combatMatch
+
+	for i, d := range rd.Declarations {
+		r := rd.Rolls[i]
+
+		for j, a := range d.Actions {
+			if j >= len(r.Matches) {
+				rep.Events = append(rep.Events, RoundEvent{
+					Actor:  d.Actor,
+					Action: a,
+					Target: a.Target,
+					Text:   fmt.Sprintf("%s has no match for %s", d.Actor.Name, a.Type),
+				})
+				continue
+			}
+			queue = append(queue, &combatMatch{
+				Match:  r.Matches[j],
+				decl:   d,
+				action: a,
+			})
+		}
+	}
+
+	sort.SliceStable(queue, func(i, j int) bool {
+		return ByWidthHeight{queue[i].Match, queue[j].Match}.Less(0, 1)
+	})
+
+	for _, cm := range queue {
+		cm.done = true
+		rep.Events = append(rep.Events, rd.resolveMatch(cm, queue))
+	}
+
+	rd.phase = phaseResolve
+
+	return rep, nil
+}
+
+// resolveMatch plays out a single allocated match
+func (rd *Round) resolveMatch(cm *combatMatch, queue []*combatMatch) RoundEvent {
+
+	name := cm.decl.Actor.Name
+
+	ev := RoundEvent{
+		Actor:  cm.decl.Actor,
+		Action: cm.action,
+		Match:  cm.Match,
+		Target: cm.action.Target,
+	}
+
+	if cm.broken {
+		ev.Broken = true
+		ev.Text = fmt.Sprintf("%s's %s was knocked out before it could act", name, cm.action.Type)
+		return ev
+	}
+
+	// Wounds taken earlier in the round can drop an actor before they act
+	if st := cm.decl.Actor.Status(); (st.Dead || st.Unconscious) && !cm.decl.Options.IgnoreWounds {
+		ev.Broken = true
+		ev.Text = fmt.Sprintf("%s cannot %s: %s", name, cm.action.Type, st)
+		return ev
+	}
+
+	switch cm.action.Type {
+
+	case Attack:
+		t := cm.action.Target
+
+		ev.Gobbled = gobble(cm, queue)
+		ev.Match = cm.Match
+
+		if cm.broken {
+			ev.Broken = true
+			ev.Text = fmt.Sprintf("%s's attack on %s at height %d was gobbled by %s",
+				name, t.Name, cm.Height, gobblers(ev.Gobbled))
+			return ev
+		}
+
+		ev.Location = t.LocationAt(cm.Height)
+
+		loc := "no location"
+		if ev.Location != nil {
+			loc = ev.Location.Name
+		}
+
+		ev.Text = fmt.Sprintf("%s attacks %s with %dx%d, striking %s",
+			name, t.Name, cm.Width, cm.Height, loc)
+
+		spec := cm.action.Damage
+		if spec == nil {
+			spec = &Unarmed
+		}
+
+		if wr, err := t.ApplyHit(cm.Match, *spec); err == nil {
+			ev.Wound = wr
+			if wr.Blocked {
+				ev.Text += fmt.Sprintf(", stopped by HAR %d", wr.HAR)
+			} else {
+				ev.Text += fmt.Sprintf(" for %s", wr.Damage)
+			}
+		}
+
+		if len(ev.Gobbled) > 0 {
+			ev.Text += fmt.Sprintf(" after gobble dice from %s", gobblers(ev.Gobbled))
+		}
+
+		if v := knockDie(t, queue); v != nil {
+			ev.Text += fmt.Sprintf(" and knocking a die from %s's %dx%d",
+				t.Name, v.Width+1, v.Height)
+			if v.broken {
+				ev.Text += ", breaking it"
+			}
+		}
+
+	case Defend:
+		ev.Text = fmt.Sprintf("%s defends", name)
+		if cm.action.Target != nil && cm.action.Target != cm.decl.Actor {
+			ev.Text += " " + cm.action.Target.Name
+		}
+		ev.Text += fmt.Sprintf(" with %dx%d, %d gobble dice ready",
+			cm.Width, cm.Height, cm.gobbleDice())
+
+	case Useful:
+		ev.Text = fmt.Sprintf("%s succeeds at %s with %dx%d",
+			name, cm.action.Effect, cm.Width, cm.Height)
+	}
+
+	return ev
+}
+
+// knockDie removes a die from the target's widest match that has not acted
+func knockDie(target *Character, queue []*combatMatch) *combatMatch {
+
+	var victim *combatMatch
+
+	for _, cm := range queue {
+		if cm.decl.Actor != target || cm.done || cm.broken {
+			continue
+		}
+		if victim == nil || cm.Width > victim.Width ||
+			(cm.Width == victim.Width && cm.Height > victim.Height) {
+			victim = cm
+		}
+	}
+
+	if victim != nil {
+		victim.Width--
+		if victim.Width < 2 {
+			victim.broken = true
+		}
+	}
+	return victim
+}
+
+// gobble spends defenders' gobble dice against an attack, fastest defense
+// first, until the attack is broken or no eligible dice remain
+func gobble(attack *combatMatch, queue []*combatMatch) []Gobble {
+
+	var spent []Gobble
+
+	for _, d := range queue {
+		if attack.broken {
+			break
+		}
+
+		if !d.protects(attack.action.Target) ||
+			d.Height < attack.Height ||
+			d.Initiative < attack.Initiative {
+			continue
+		}
+
+		n := d.gobbleDice()
+		if n == 0 {
+			continue
+		}
+
+		// Spend only what is needed to break the attack
+		n = Min(n, attack.Width-1)
+
+		d.spent += n
+		attack.Width -= n
+
+		if attack.Width < 2 {
+			attack.broken = true
+		}
+
+		spent = append(spent, Gobble{
+			Defender: d.decl.Actor,
+			Height:   d.Height,
+			Dice:     n,
+		})
+	}
+	return spent
+}
+
+// gobblers describes who spent gobble dice
+func gobblers(gs []Gobble) string {
+
+	var names []string
+	for _, g := range gs {
+		names = append(names, fmt.Sprintf("%s (%dx%d)", g.Defender.Name, g.Dice, g.Height))
+	}
+	return strings.Join(names, ", ")
+}
+
+// RunRound declares, rolls and resolves a round in one call
+func RunRound(src RandomSource, decls ...Declaration) (*RoundReport, error) {
+
+	rd := NewRound(src)
+
+	for _, d := range decls {
+		if err := rd.Declare(d); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := rd.Roll(); err != nil {
+		return nil, err
+	}
+
+	return rd.Resolve()
+}
+
+func (rep RoundReport) String() string {
+
+	text := "***Round***\n"
+
+	for _, r := range rep.Rolls {
+		text += fmt.Sprintf("%s rolls %s: %s\n",
+			r.Actor.Name, r.DiePool, TrimSliceBrackets(r.Results))
+	}
+
+	text += "\n"
+
+	for i, ev := range rep.Events {
+		text += fmt.Sprintf("%d: %s\n", i+1, ev.Text)
+	}
+
+	return text
+}
+
+// CombatTrial runs a Round of decls for Simulate. The winner is the actor
+// who dealt the most damage, and Damage totals the wounds by location.
+// Every character's wounds are restored after each trial.
+func CombatTrial(decls ...Declaration) Trial {
+
+	return func(src RandomSource) TrialResult {
+
+		t := TrialResult{Damage: map[string]int{}}
+
+		var chars []*Character
+		for _, d := range decls {
+			chars = append(chars, d.Actor)
+			for _, a := range d.Actions {
+				chars = append(chars, a.Target)
+			}
+		}
+		defer keepWounds(chars...)()
+
+		rep, err := RunRound(src, decls...)
+		if err != nil {
+			t.Err = err
+			return t
+		}
+
+		dealt := map[string]int{}
+		seen := map[*Character]bool{}
+
+		for _, ev := range rep.Events {
+			if ev.Broken || ev.Match.Width == 0 {
+				continue
+			}
+
+			if !seen[ev.Actor] {
+				seen[ev.Actor] = true
+				t.Order = append(t.Order, ev.Actor.Name)
+			}
+
+			if ev.Wound != nil {
+				dealt[ev.Actor.Name] += addWounds(t.Damage, ev.Wound)
+			}
+
+		}
+
+		best, tied := 0, false
+		for name, d := range dealt {
+			switch {
+			case d > best:
+				t.Winner, best, tied = name, d, false
+			case d == best:
+				tied = true
+			}
+		}
+
+		if tied {
+			t.Winner = ""
+		}
+
+		return t
+	}
+}
@@ -0,0 +1,488 @@
+package oneroll
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// CostItem explains one part of a point cost. Items break the Cost down,
+// so a Power lists its cost per die, each Quality and Modifier within it,
+// and what each type of die adds.
+type CostItem struct {
+	Name   string
+	Cost   int  // Points, or points per die if PerDie
+	PerDie bool // Cost is charged for every die
+	Note   string
+	Items  []*CostItem
+}
+
+// add appends child and returns it
+func (ci *CostItem) add(child *CostItem) *CostItem {
+	ci.Items = append(ci.Items, child)
+	return child
+}
+
+// Find returns the first item below ci named name, or nil
+func (ci *CostItem) Find(name string) *CostItem {
+
+	for _, i := range ci.Items {
+		if i.Name == name {
+			return i
+		}
+		if f := i.Find(name); f != nil {
+			return f
+		}
+	}
+	return nil
+}
+
+func (ci CostItem) String() string {
+	return ci.format(0)
+}
+
+func (ci CostItem) format(depth int) string {
+
+	text := strings.Repeat("  ", depth)
+
+	if ci.PerDie {
+		text += fmt.Sprintf("%s: %+d/die", ci.Name, ci.Cost)
+	} else {
+		text += fmt.Sprintf("%s: %dpts", ci.Name, ci.Cost)
+	}
+
+	if ci.Note != "" {
+		text += fmt.Sprintf(" (%s)", ci.Note)
+	}
+	text += "\n"
+
+	for _, i := range ci.Items {
+		text += i.format(depth + 1)
+	}
+	return text
+}
+
+// explainPool prices d at the per die cost explained by perDie
+func (cf CostFramework) explainPool(name string, perDie *CostItem, d *DiePool) *CostItem {
+
+	ci := &CostItem{Name: name}
+	ci.add(perDie)
+
+	dice := ci.add(cf.explainDice(perDie.Cost, d))
+	ci.Cost = dice.Cost
+
+	return ci
+}
+
+// explainDice prices each type of die in d at b per die
+func (cf CostFramework) explainDice(b int, d *DiePool) *CostItem {
+
+	ci := &CostItem{Name: "Dice"}
+
+	if d == nil {
+		return ci
+	}
+
+	for _, t := range []struct {
+		name  string
+		n     int
+		mult  int
+		label string
+	}{
+		{"Normal", d.Normal, 1, "d"},
+		{"Hard", d.Hard, cf.HardMult, "hd"},
+		{"Wiggle", d.Wiggle, cf.WiggleMult, "wd"},
+		{"Expert", len(d.Expert), cf.ExpertMult, "ed"},
+	} {
+		if t.n == 0 {
+			continue
+		}
+
+		note := fmt.Sprintf("%d%s x %d/die", t.n, t.label, b)
+		if t.mult != 1 {
+			note += fmt.Sprintf(" x%d", t.mult)
+		}
+
+		c := b * t.mult * t.n
+		ci.add(&CostItem{Name: t.name, Cost: c, Note: note})
+		ci.Cost += c
+	}
+	return ci
+}
+
+// perDieItem sums parts into a cost per die
+func perDieItem(parts ...*CostItem) *CostItem {
+
+	ci := &CostItem{Name: "Cost per die", PerDie: true}
+
+	for _, p := range parts {
+		if p == nil {
+			continue
+		}
+		ci.add(p)
+		ci.Cost += p.Cost
+	}
+	return ci
+}
+
+// explainModifiers lists Modifiers on a Statistic or Skill. There are
+// mods, but flaws can't reduce the cost below 1. Returns nil for no mods.
+func explainModifiers(mods []*Modifier) *CostItem {
+
+	if len(mods) == 0 {
+		return nil
+	}
+
+	ci := &CostItem{Name: "Modifiers", PerDie: true}
+
+	for _, m := range mods {
+		ci.add(explainModifier(*m, ""))
+		ci.Cost += m.Price()
+	}
+
+	if ci.Cost < 1 {
+		ci.Note = fmt.Sprintf("%+d raised to the minimum of +1/die", ci.Cost)
+		ci.Cost = 1
+	}
+	return ci
+}
+
+// explainModifier describes a single Modifier
+func explainModifier(m Modifier, note string) *CostItem {
+
+	name := m.Name
+	if m.RequiresLevel {
+		name += fmt.Sprintf(" %d", m.Level)
+		if note == "" && m.Level != 1 {
+			note = fmt.Sprintf("%+d x %d levels", m.CostPerLevel, m.Level)
+		}
+	}
+
+	return &CostItem{Name: name, Cost: m.Price(), PerDie: true, Note: note}
+}
+
+// explainQuality prices a Quality on top of base b with any implied
+// Modifiers, never below min per die
+func explainQuality(q *Quality, b, min int, minNote string, implied ...Modifier) *CostItem {
+
+	ci := &CostItem{Name: fmt.Sprintf("%s (%s)", q.Type, q.Name), PerDie: true}
+
+	if b != 0 {
+		ci.add(&CostItem{Name: "Base", Cost: b, PerDie: true})
+	}
+
+	if q.Level != 0 {
+		ci.add(&CostItem{Name: "Level", Cost: q.Level, PerDie: true})
+	}
+
+	for _, m := range q.Modifiers {
+		ci.add(explainModifier(*m, ""))
+	}
+
+	for _, m := range implied {
+		ci.add(explainModifier(m, fmt.Sprintf("implied by %d capacities", len(q.Capacities))))
+	}
+
+	for _, i := range ci.Items {
+		ci.Cost += i.Cost
+	}
+
+	if ci.Cost < min {
+		ci.Note = fmt.Sprintf("%+d raised to %s", ci.Cost, minNote)
+		ci.Cost = min
+	}
+	return ci
+}
+
+// ExplainCost itemizes the Statistic's cost using cf
+func (s *Statistic) ExplainCost(cf CostFramework) *CostItem {
+
+	var levels *CostItem
+
+	// Add cost for HyperStat levels applied to Stat
+	if s.HyperStat != nil {
+		for _, q := range s.HyperStat.Qualities {
+			if q.Level > 0 {
+				if levels == nil {
+					levels = &CostItem{Name: "HyperStat levels", PerDie: true}
+				}
+				levels.Cost += q.Level
+			}
+		}
+	}
+
+	per := perDieItem(
+		&CostItem{Name: "Base", Cost: cf.Stat, PerDie: true},
+		levels,
+		explainModifiers(s.Modifiers),
+	)
+
+	return cf.explainPool(s.Name, per, s.Dice)
+}
+
+// ExplainCost itemizes the Skill's cost using cf
+func (s *Skill) ExplainCost(cf CostFramework) *CostItem {
+
+	parts := s.baseItems(cf)
+
+	// Add cost for HyperSkill levels applied to Stat
+	if s.HyperSkill != nil {
+		levels := &CostItem{Name: "HyperSkill levels", PerDie: true}
+		for _, q := range s.HyperSkill.Qualities {
+			if q.Level > 0 {
+				levels.Cost += q.Level
+			}
+		}
+		if levels.Cost > 0 {
+			parts = append(parts, levels)
+		}
+	}
+
+	parts = append(parts, explainModifiers(s.Modifiers))
+
+	return cf.explainPool(s.Name, perDieItem(parts...), s.Dice)
+}
+
+// baseItems explains the Skill's cost per die before levels and modifiers
+func (s *Skill) baseItems(cf CostFramework) []*CostItem {
+
+	base := &CostItem{Name: "Base", Cost: cf.Skill, PerDie: true}
+	if s.Free {
+		base.Cost = 0
+		base.Note = "free"
+	}
+
+	items := []*CostItem{base}
+
+	if s.Narrow {
+		items = append(items, &CostItem{Name: "Narrow", Cost: -1, PerDie: true})
+	}
+
+	if s.Flexible {
+		items = append(items, &CostItem{Name: "Flexible", Cost: 1, PerDie: true})
+	}
+
+	if s.Influence {
+		items = append(items, &CostItem{Name: "Influence", Cost: 1, PerDie: true})
+	}
+
+	return items
+}
+
+// ExplainCost itemizes the HyperStat's cost using cf. Qualities with more
+// than three Capacities include an implied Power Capacity Modifier.
+func (hs *HyperStat) ExplainCost(cf CostFramework) *CostItem {
+
+	parts := []*CostItem{{Name: "Base", Cost: cf.HyperStat, PerDie: true}}
+
+	for _, q := range hs.Qualities {
+		parts = append(parts, explainQuality(q, 0, 0, "0/die", q.impliedCapacity(3)...))
+	}
+
+	return cf.explainPool(hs.Name, perDieItem(parts...), hs.Dice)
+}
+
+// ExplainCost itemizes the HyperSkill's cost using cf. Qualities with more
+// than one Capacity include an implied Power Capacity Modifier.
+func (hs *HyperSkill) ExplainCost(cf CostFramework) *CostItem {
+
+	parts := []*CostItem{{Name: "Base", Cost: cf.HyperSkill, PerDie: true}}
+
+	for _, q := range hs.Qualities {
+		parts = append(parts, explainQuality(q, 0, 0, "0/die", q.impliedCapacity(1)...))
+	}
+
+	return cf.explainPool(hs.Name, perDieItem(parts...), hs.Dice)
+}
+
+// ExplainCost itemizes the Power's cost using cf
+func (p *Power) ExplainCost(cf CostFramework) *CostItem {
+
+	var parts []*CostItem
+
+	for _, q := range p.Qualities {
+		parts = append(parts, explainQuality(q, cf.Quality, 1, "the minimum of 1/die per Quality"))
+	}
+
+	return cf.explainPool(p.Name, perDieItem(parts...), p.Dice)
+}
+
+// ExplainCost itemizes the Archetype's Sources, Permissions and Intrinsics
+func (a *Archetype) ExplainCost() *CostItem {
+
+	ci := &CostItem{Name: fmt.Sprintf("Archetype (%s)", a.Type)}
+
+	for i, s := range a.Sources {
+		item := &CostItem{Name: "Source: " + s.Type, Cost: a.sourceCost(i)}
+		if item.Cost != s.Cost {
+			item.Note = "first Source is free"
+		}
+		ci.add(item)
+	}
+
+	for _, p := range a.Permissions {
+		ci.add(&CostItem{Name: "Permission: " + p.Type, Cost: p.Cost})
+	}
+
+	for _, i := range a.Intrinsics {
+		item := &CostItem{Name: "Intrinsic: " + i.Name, Cost: i.Cost}
+		if i.RequiresLevel {
+			item.Cost = i.Cost * i.Level
+			item.Note = fmt.Sprintf("%d x %d levels", i.Cost, i.Level)
+		}
+		ci.add(item)
+	}
+
+	for _, i := range ci.Items {
+		ci.Cost += i.Cost
+	}
+	return ci
+}
+
+// ExplainCost itemizes the Advantage's cost
+func (a *Advantage) ExplainCost() *CostItem {
+
+	if a.RequiresLevel {
+		return &CostItem{
+			Name: a.Name,
+			Cost: a.Cost * a.Level,
+			Note: fmt.Sprintf("%d x %d levels", a.Cost, a.Level),
+		}
+	}
+	return &CostItem{Name: a.Name, Cost: a.Cost}
+}
+
+// ExplainCost itemizes every element of the Character's point cost,
+// grouped into the categories of DetailedCost. Powers include HyperStats
+// and HyperSkills. It changes nothing, so a Character whose BaseWill has
+// not been set is explained as CalculateCost would set it.
+func (c *Character) ExplainCost() *CostItem {
+
+	cf := c.CostFramework()
+
+	root := &CostItem{Name: c.Name}
+
+	archetype := &CostItem{Name: "Archetype"}
+	stats := &CostItem{Name: "Stats"}
+	skills := &CostItem{Name: "Skills"}
+	powers := &CostItem{Name: "Powers"}
+	advantages := &CostItem{Name: "Advantages"}
+	willpower := &CostItem{Name: "Willpower"}
+	baseWill := &CostItem{Name: "Base Will"}
+
+	if c.Setting != "RE" && c.Archetype != nil && len(c.Archetype.Sources) > 0 {
+		archetype.add(c.Archetype.ExplainCost())
+	}
+
+	for _, name := range c.statNames() {
+		stat := c.Statistics[name]
+		stats.add(stat.ExplainCost(cf))
+
+		if stat.HyperStat != nil {
+			powers.add(stat.HyperStat.ExplainCost(cf))
+		}
+	}
+
+	for _, name := range sortedSkillNames(c.Skills) {
+		skill := c.Skills[name]
+		skills.add(skill.ExplainCost(cf))
+
+		if skill.HyperSkill != nil {
+			powers.add(skill.HyperSkill.ExplainCost(cf))
+		}
+	}
+
+	var powerNames []string
+	for k := range c.Powers {
+		powerNames = append(powerNames, k)
+	}
+	sort.Strings(powerNames)
+
+	for _, name := range powerNames {
+		powers.add(c.Powers[name].ExplainCost(cf))
+	}
+
+	for _, a := range c.Advantages {
+		advantages.add(a.ExplainCost())
+	}
+
+	if c.Setting != "RE" {
+		calc := c.calcBaseWill()
+
+		bw := c.BaseWill
+		if bw == 0 {
+			bw = calc
+		}
+
+		wp := c.Willpower
+		if c.BaseWill == 0 {
+			wp = bw
+		}
+
+		baseWill.Cost = 3 * (bw - calc)
+		baseWill.Note = fmt.Sprintf("3 x (%d Base Will - %d from Stats)", bw, calc)
+
+		willpower.Cost = wp - bw
+		willpower.Note = fmt.Sprintf("%d Willpower - %d Base Will", wp, bw)
+	}
+
+	for _, cat := range []*CostItem{archetype, stats, skills, powers, advantages, willpower, baseWill} {
+		for _, i := range cat.Items {
+			cat.Cost += i.Cost
+		}
+		root.add(cat)
+		root.Cost += cat.Cost
+	}
+
+	return root
+}
+
+// calcBaseWill totals the dice of Statistics that count toward Base Will
+func (c *Character) calcBaseWill() int {
+
+	calc := 0
+	for _, stat := range c.Statistics {
+		if stat.EffectsWill {
+			calc += SumDice(stat.Dice)
+			if stat.HyperStat != nil {
+				calc += SumDice(stat.HyperStat.Dice)
+			}
+		}
+	}
+	return calc
+}
+
+// statNames lists Statistics in StatMap order, then any others by name
+func (c *Character) statNames() []string {
+
+	var names []string
+	seen := map[string]bool{}
+
+	for _, name := range c.StatMap {
+		if _, ok := c.Statistics[name]; ok && !seen[name] {
+			names = append(names, name)
+			seen[name] = true
+		}
+	}
+
+	var rest []string
+	for k := range c.Statistics {
+		if !seen[k] {
+			rest = append(rest, k)
+		}
+	}
+	sort.Strings(rest)
+
+	return append(names, rest...)
+}
+
+// sortedSkillNames lists skill names alphabetically
+func sortedSkillNames(skills map[string]*Skill) []string {
+
+	var names []string
+	for k := range skills {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	return names
+}
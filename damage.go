@@ -0,0 +1,551 @@
+package oneroll
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Damage is an amount of Shock and Killing damage
+type Damage struct {
+	Shock   int
+	Killing int
+}
+
+func (d Damage) String() string {
+
+	var parts []string
+
+	if d.Shock > 0 {
+		parts = append(parts, fmt.Sprintf("%d Shock", d.Shock))
+	}
+
+	if d.Killing > 0 {
+		parts = append(parts, fmt.Sprintf("%d Killing", d.Killing))
+	}
+
+	if len(parts) == 0 {
+		return "no damage"
+	}
+	return strings.Join(parts, " + ")
+}
+
+// DamageExpr scales a Match's width into damage, like "Width+1 Killing".
+// A Multiplier of 0 deals a fixed amount.
+type DamageExpr struct {
+	Multiplier int // Width is multiplied by this
+	Bonus      int // then this is added
+	Killing    bool
+}
+
+// Evaluate returns the damage a Match deals, never less than zero
+func (d DamageExpr) Evaluate(m Match) Damage {
+
+	amount := Max(d.Multiplier*m.Width+d.Bonus, 0)
+
+	if d.Killing {
+		return Damage{Killing: amount}
+	}
+	return Damage{Shock: amount}
+}
+
+func (d DamageExpr) String() string {
+
+	var text string
+
+	switch d.Multiplier {
+	case 0:
+		text = fmt.Sprintf("%d", d.Bonus)
+	case 1:
+		text = "W"
+	default:
+		text = fmt.Sprintf("%dW", d.Multiplier)
+	}
+
+	if d.Multiplier > 0 && d.Bonus > 0 {
+		text += fmt.Sprintf("+%d", d.Bonus)
+	}
+	if d.Multiplier > 0 && d.Bonus < 0 {
+		text += fmt.Sprintf("%d", d.Bonus)
+	}
+
+	if d.Killing {
+		return text + " K"
+	}
+	return text + " S"
+}
+
+// DamageSpec is all the damage an attack deals, such as a weapon
+// doing both Shock and Killing
+type DamageSpec struct {
+	Terms       []DamageExpr
+	Penetration int // Armor ignored, HAR first then LAR
+	Area        int // Area dice rolled against everyone in range
+}
+
+// Unarmed is the Width Shock dealt by an attack with no other DamageSpec
+var Unarmed = DamageSpec{Terms: []DamageExpr{{Multiplier: 1}}}
+
+// Evaluate totals the damage of every term for a Match
+func (s DamageSpec) Evaluate(m Match) Damage {
+
+	var d Damage
+
+	for _, t := range s.Terms {
+		td := t.Evaluate(m)
+		d.Shock += td.Shock
+		d.Killing += td.Killing
+	}
+	return d
+}
+
+// String writes the DamageSpec in the form read by ParseDamage
+func (s DamageSpec) String() string {
+
+	var terms []string
+	for _, t := range s.Terms {
+		terms = append(terms, t.String())
+	}
+
+	text := strings.Join(terms, " + ")
+
+	if s.Area > 0 {
+		text += fmt.Sprintf(", area %dd", s.Area)
+	}
+
+	if s.Penetration > 0 {
+		text += fmt.Sprintf(", pen %d", s.Penetration)
+	}
+
+	return text
+}
+
+// ParseDamage reads ORE damage expressions into a DamageSpec.
+// Terms are joined by + and each is a width multiple with an optional
+// bonus and a kind, S (Shock) or K (Killing): "W+1S", "W K", "2W S + W K".
+// A number without W deals fixed damage, as in "1 K". Options follow
+// after commas: "area 2d" and "pen 1". Width, Shock, Kill, Killing and
+// Penetration may be spelled out, and case and spacing are ignored.
+func ParseDamage(expr string) (*DamageSpec, error) {
+	return parseDamage(expr, "")
+}
+
+// parseDamage reads a damage expression. If kind is "s" or "k", terms
+// without a kind take it, so a Weapon's Kill field may just say "W+1".
+func parseDamage(expr, kind string) (*DamageSpec, error) {
+
+	tokens, err := tokenize(expr)
+	if err != nil {
+		err.(*NotationError).Notation = "damage"
+		return nil, err
+	}
+
+	p := &notationParser{
+		input:    expr,
+		tokens:   tokens,
+		seen:     map[string]bool{},
+		notation: "damage",
+	}
+
+	spec := &DamageSpec{}
+
+	if p.peek().kind == tokEOF {
+		return nil, p.errorAt(p.peek(), "empty damage expression")
+	}
+
+	for {
+		t, err := p.parseDamageTerm(kind)
+		if err != nil {
+			return nil, err
+		}
+		spec.Terms = append(spec.Terms, t)
+
+		if p.peek().kind != tokPlus {
+			break
+		}
+		p.next()
+	}
+
+	for p.peek().kind == tokComma {
+		p.next()
+		if err := p.parseDamageOption(spec); err != nil {
+			return nil, err
+		}
+	}
+
+	if t := p.peek(); t.kind != tokEOF {
+		return nil, p.errorAt(t, "expected + or , between terms")
+	}
+
+	return spec, nil
+}
+
+// damageKind reads a Shock or Killing word
+func damageKind(w string) (killing, ok bool) {
+	switch w {
+	case "s", "shock":
+		return false, true
+	case "k", "kill", "killing":
+		return true, true
+	}
+	return false, false
+}
+
+// isWidth matches W or Width, alone or with a kind attached as in "WK"
+func isWidth(w string) (rest string, ok bool) {
+	for _, prefix := range []string{"width", "w"} {
+		if strings.HasPrefix(w, prefix) {
+			rest = strings.TrimPrefix(w, prefix)
+			if _, k := damageKind(rest); rest == "" || k {
+				return rest, true
+			}
+		}
+	}
+	return "", false
+}
+
+// parseDamageTerm reads one [N]W[+N] kind or N kind term
+func (p *notationParser) parseDamageTerm(defaultKind string) (DamageExpr, error) {
+
+	d := DamageExpr{}
+	start := p.peek()
+
+	n := -1
+	if start.kind == tokNumber {
+		n, _ = strconv.Atoi(p.next().text)
+	}
+
+	kind := ""
+	t := p.peek()
+
+	if rest, ok := isWidth(t.text); ok && t.kind == tokWord {
+		p.next()
+
+		d.Multiplier = 1
+		if n >= 0 {
+			d.Multiplier = n
+		}
+		if d.Multiplier < 1 {
+			return d, p.errorAt(start, "width multiplier must be at least 1")
+		}
+
+		kind = rest
+
+		// A + or - followed by a plain number is a bonus, not a new term
+		if kind == "" && (p.peek().kind == tokPlus || p.peek().kind == tokMinus) &&
+			p.tokens[p.pos+1].kind == tokNumber {
+
+			after := p.tokens[p.pos+2]
+			if _, w := isWidth(after.text); !(after.kind == tokWord && w) {
+				sign := p.next()
+				b, _ := strconv.Atoi(p.next().text)
+				if sign.kind == tokMinus {
+					b = -b
+				}
+				d.Bonus = b
+			}
+		}
+	} else {
+		if n < 0 {
+			return d, p.errorAt(t, "expected W or a number")
+		}
+		d.Bonus = n
+	}
+
+	if kind == "" && p.peek().kind == tokWord {
+		if _, ok := damageKind(p.peek().text); ok {
+			kind = p.next().text
+		}
+	}
+
+	if kind == "" {
+		kind = defaultKind
+	}
+
+	killing, ok := damageKind(kind)
+	if !ok {
+		return d, p.errorAt(p.peek(), "expected S (Shock) or K (Killing)")
+	}
+	d.Killing = killing
+
+	return d, nil
+}
+
+// parseDamageOption reads an area or penetration option
+func (p *notationParser) parseDamageOption(spec *DamageSpec) error {
+
+	name := p.next()
+	if name.kind != tokWord {
+		return p.errorAt(name, "expected area or pen")
+	}
+
+	if p.seen[name.text] {
+		return p.errorAt(name, "option given more than once")
+	}
+	p.seen[name.text] = true
+
+	num := p.next()
+	if num.kind != tokNumber {
+		return p.errorAt(num, "expected a number")
+	}
+	n, err := strconv.Atoi(num.text)
+	if err != nil || n > maxCount {
+		return p.errorAt(num, fmt.Sprintf("number must be no more than %d", maxCount))
+	}
+
+	switch name.text {
+	case "area":
+		if n < 1 {
+			return p.errorAt(num, "area must be at least 1d")
+		}
+		// The d in 2d is optional
+		if t := p.peek(); t.kind == tokWord && t.text == "d" {
+			p.next()
+		}
+		spec.Area = n
+	case "pen", "penetration":
+		spec.Penetration = n
+	default:
+		return p.errorAt(name, "unknown damage option")
+	}
+	return nil
+}
+
+// Wound is damage marked on a single Location
+type Wound struct {
+	Location  string
+	Shock     int  // Empty boxes filled with Shock
+	Killing   int  // Empty boxes filled with Killing
+	Converted int  // Shock boxes turned to Killing
+	Overflow  bool // Damage carried over from a full location
+}
+
+func (w Wound) String() string {
+
+	text := fmt.Sprintf("%s:", w.Location)
+
+	if w.Shock > 0 {
+		text += fmt.Sprintf(" %d Shock", w.Shock)
+	}
+	if w.Killing > 0 {
+		text += fmt.Sprintf(" %d Killing", w.Killing)
+	}
+	if w.Converted > 0 {
+		text += fmt.Sprintf(" %d Shock converted to Killing", w.Converted)
+	}
+	if w.Overflow {
+		text += " (overflow)"
+	}
+	return text
+}
+
+// WoundReport itemizes the result of applying damage to a Character
+type WoundReport struct {
+	Target      *Character
+	Match       Match
+	HAR         int  // HAR left after penetration, taken from width
+	LAR         int  // LAR left after penetration, taken from Shock
+	Blocked     bool // HAR reduced the attack below a pair
+	Absorbed    Damage
+	Damage      Damage
+	Wounds      []Wound
+	Disabled    []string // Locations newly filled by this damage
+	Lost        int      // Damage with nowhere left to go
+	Unconscious bool
+	Dead        bool
+}
+
+func (wr WoundReport) String() string {
+
+	if wr.Blocked {
+		return fmt.Sprintf("%s's armor (HAR %d) stops the attack\n", wr.Target.Name, wr.HAR)
+	}
+
+	text := fmt.Sprintf("%s takes %s\n", wr.Target.Name, wr.Damage)
+
+	if wr.Absorbed != (Damage{}) {
+		text += fmt.Sprintf("Armor absorbs %s\n", wr.Absorbed)
+	}
+
+	for _, w := range wr.Wounds {
+		text += fmt.Sprintf("-- %s\n", w)
+	}
+
+	if len(wr.Disabled) > 0 {
+		text += fmt.Sprintf("Disabled: %s\n", strings.Join(wr.Disabled, ", "))
+	}
+
+	switch {
+	case wr.Dead:
+		text += fmt.Sprintf("%s is dead\n", wr.Target.Name)
+	case wr.Unconscious:
+		text += fmt.Sprintf("%s is unconscious\n", wr.Target.Name)
+	}
+
+	return text
+}
+
+// ApplyDamage wounds the Character at the Location hit by m's height using
+// a damage expression such as "Width Shock" or "W+1 K, pen 1"
+func (c *Character) ApplyDamage(m Match, expr string) (*WoundReport, error) {
+
+	spec, err := ParseDamage(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.ApplyHit(m, *spec)
+}
+
+// ApplyWeapon wounds the Character with a Weapon's DamageSpec
+func (c *Character) ApplyWeapon(m Match, w *Weapon) (*WoundReport, error) {
+
+	spec, err := w.DamageSpec()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.ApplyHit(m, *spec)
+}
+
+// ApplyHit wounds the Character with spec through the armor on the Location
+// hit by m's height. Penetration first lowers HAR, then LAR. HAR is taken
+// from the attack's width, and an attack left with less than a pair does
+// nothing. LAR is then taken from the Shock dealt.
+func (c *Character) ApplyHit(m Match, spec DamageSpec) (*WoundReport, error) {
+
+	loc := c.LocationAt(m.Height)
+	if loc == nil {
+		return nil, fmt.Errorf("%s has no hit location at height %d", c.Name, m.Height)
+	}
+
+	har := Max(loc.HAR-spec.Penetration, 0)
+	lar := Max(loc.LAR-Max(spec.Penetration-loc.HAR, 0), 0)
+
+	hit := m
+	hit.Width -= har
+
+	if hit.Width < 2 {
+		return &WoundReport{
+			Target:  c,
+			Match:   m,
+			HAR:     har,
+			LAR:     lar,
+			Blocked: true,
+		}, nil
+	}
+
+	full := spec.Evaluate(m)
+	d := spec.Evaluate(hit)
+	d.Shock = Max(d.Shock-lar, 0)
+
+	wr := c.ApplyWounds(loc, d)
+	wr.Match = m
+	wr.HAR = har
+	wr.LAR = lar
+	wr.Absorbed = Damage{
+		Shock:   full.Shock - d.Shock,
+		Killing: full.Killing - d.Killing,
+	}
+
+	return wr, nil
+}
+
+// ApplyWounds marks damage on loc. Killing fills empty boxes first, then
+// converts Shock. Shock fills empty boxes, then converts existing Shock to
+// Killing. Damage a full limb cannot take carries over to the Body. A full
+// location is Disabled; a full Head or Body knocks the Character out, or
+// kills them if every box is Killing.
+func (c *Character) ApplyWounds(loc *Location, d Damage) *WoundReport {
+
+	wr := &WoundReport{
+		Target: c,
+		Damage: d,
+	}
+
+	body := c.HitLocations["Body"]
+
+	wasDisabled := map[*Location]bool{}
+	for _, l := range c.HitLocations {
+		wasDisabled[l] = l.Disabled
+	}
+
+	w := Wound{Location: loc.Name}
+	var over Damage
+
+	loc.padWounds()
+
+	for i := 0; i < d.Killing; i++ {
+		switch {
+		case loc.markEmpty(true):
+			w.Killing++
+		case loc.convertShock():
+			w.Converted++
+		default:
+			over.Killing++
+		}
+	}
+
+	for i := 0; i < d.Shock; i++ {
+		switch {
+		case loc.markEmpty(false):
+			w.Shock++
+		case loc.convertShock():
+			w.Converted++
+		default:
+			over.Shock++
+		}
+	}
+
+	wr.Wounds = append(wr.Wounds, w)
+
+	// Limbs pass excess damage to the Body
+	if over != (Damage{}) {
+		if body != nil && loc != body && loc.Name != "Head" {
+			ow := c.ApplyWounds(body, over)
+			for _, bw := range ow.Wounds {
+				bw.Overflow = true
+				wr.Wounds = append(wr.Wounds, bw)
+			}
+			wr.Lost += ow.Lost
+		} else {
+			wr.Lost += over.Shock + over.Killing
+		}
+	}
+
+	for _, name := range c.LocationMap {
+		l := c.HitLocations[name]
+		if l == nil {
+			continue
+		}
+		if l.Full() {
+			l.Disabled = true
+		}
+		if l.Disabled && !wasDisabled[l] {
+			wr.Disabled = append(wr.Disabled, l.Name)
+		}
+	}
+
+	wr.Unconscious, wr.Dead = c.vitalState()
+
+	return wr
+}
+
+// vitalState reports whether a full Head or Body has knocked the
+// Character out, or killed them when filled with Killing
+func (c *Character) vitalState() (unconscious, dead bool) {
+
+	for _, name := range []string{"Head", "Body"} {
+		l := c.HitLocations[name]
+		if l == nil || !l.Full() {
+			continue
+		}
+
+		unconscious = true
+
+		if kill, _ := l.CountWounds(); kill >= l.Boxes {
+			dead = true
+		}
+	}
+	return unconscious, dead
+}
@@ -1,5 +1,10 @@
 package oneroll
 
+import (
+	"fmt"
+	"strings"
+)
+
 // Weapon models a weapon in ORE
 type Weapon struct {
 	ID          int64
@@ -15,6 +20,47 @@ type Weapon struct {
 	Penetration int
 }
 
+// DamageSpec parses the Weapon's Shock and Kill fields into a single
+// DamageSpec. Each field is a damage expression whose terms default to
+// its own kind, so Kill may be "W+1" or "W+1 K". Penetration and Area
+// from the Weapon are added unless the expressions set them.
+func (w *Weapon) DamageSpec() (*DamageSpec, error) {
+
+	spec := &DamageSpec{}
+
+	for _, part := range []struct{ expr, kind string }{
+		{w.Shock, "s"},
+		{w.Kill, "k"},
+	} {
+		if strings.TrimSpace(part.expr) == "" {
+			continue
+		}
+
+		ps, err := parseDamage(part.expr, part.kind)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", w.Name, err)
+		}
+
+		spec.Terms = append(spec.Terms, ps.Terms...)
+		spec.Area = Max(spec.Area, ps.Area)
+		spec.Penetration = Max(spec.Penetration, ps.Penetration)
+	}
+
+	if len(spec.Terms) == 0 {
+		return nil, fmt.Errorf("%s has no Shock or Kill damage", w.Name)
+	}
+
+	if spec.Penetration == 0 {
+		spec.Penetration = w.Penetration
+	}
+
+	if spec.Area == 0 {
+		spec.Area = w.Area
+	}
+
+	return spec, nil
+}
+
 // Armor models physical defenses in ORE
 type Armor struct {
 	ID        int64
@@ -38,3 +84,70 @@ type Material struct {
 	Vaporize int // in C
 	Burn     int // in C
 }
+
+// Ratings returns the Armor's HAR and LAR, using its Material's
+// ratings where the Armor has none of its own
+func (a *Armor) Ratings() (int, int) {
+
+	har, lar := a.HAR, a.LAR
+
+	if har == 0 {
+		har = a.Material.HAR
+	}
+	if lar == 0 {
+		lar = a.Material.LAR
+	}
+	return har, lar
+}
+
+// covers returns true if the Armor protects any height of l
+func (a *Armor) covers(l *Location) bool {
+	for _, h := range a.Locations {
+		for _, hl := range l.HitLoc {
+			if h == hl {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// EquipArmor puts on a, adding its HAR and LAR to every covered Location
+func (c *Character) EquipArmor(a *Armor) error {
+
+	for _, worn := range c.Armor {
+		if worn == a {
+			return fmt.Errorf("%s is already wearing %s", c.Name, a.Name)
+		}
+	}
+
+	c.Armor = append(c.Armor, a)
+	c.adjustArmor(a, 1)
+
+	return nil
+}
+
+// UnequipArmor takes off a, removing its HAR and LAR
+func (c *Character) UnequipArmor(a *Armor) error {
+
+	for i, worn := range c.Armor {
+		if worn == a {
+			c.Armor = append(c.Armor[:i], c.Armor[i+1:]...)
+			c.adjustArmor(a, -1)
+			return nil
+		}
+	}
+	return fmt.Errorf("%s is not wearing %s", c.Name, a.Name)
+}
+
+func (c *Character) adjustArmor(a *Armor, sign int) {
+
+	har, lar := a.Ratings()
+
+	for _, l := range c.HitLocations {
+		if a.covers(l) {
+			l.HAR += sign * har
+			l.LAR += sign * lar
+		}
+	}
+}
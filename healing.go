@@ -0,0 +1,278 @@
+package oneroll
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// HealingRules sets how quickly wounds recover with rest in a setting
+type HealingRules struct {
+	ShockEvery  time.Duration // Rest needed to heal one Shock box per location
+	KillEvery   time.Duration // Rest needed to heal one Killing box per location
+	KillToShock bool          // Killing heals into Shock rather than vanishing
+}
+
+// Healing holds the natural healing rules for each setting
+var Healing = map[string]HealingRules{
+	"WT": HealingRules{
+		ShockEvery: time.Hour,
+		KillEvery:  7 * 24 * time.Hour,
+	},
+	"SR": HealingRules{
+		ShockEvery: time.Hour,
+		KillEvery:  24 * time.Hour,
+	},
+	"RE": HealingRules{
+		ShockEvery:  24 * time.Hour,
+		KillEvery:   24 * time.Hour,
+		KillToShock: true,
+	},
+}
+
+// Healed is the damage removed from a single Location
+type Healed struct {
+	Location  string
+	Shock     int // Shock boxes cleared
+	Killing   int // Killing boxes cleared
+	Converted int // Killing boxes turned to Shock
+}
+
+func (h Healed) String() string {
+
+	text := fmt.Sprintf("%s:", h.Location)
+
+	if h.Shock > 0 {
+		text += fmt.Sprintf(" %d Shock healed", h.Shock)
+	}
+	if h.Killing > 0 {
+		text += fmt.Sprintf(" %d Killing healed", h.Killing)
+	}
+	if h.Converted > 0 {
+		text += fmt.Sprintf(" %d Killing eased to Shock", h.Converted)
+	}
+	return text
+}
+
+// RecoveryReport itemizes healing applied to a Character
+type RecoveryReport struct {
+	Target   *Character
+	Healed   []Healed
+	Restored []string // Locations no longer disabled
+	Blocked  string   // Why no healing happened, if it was prevented
+}
+
+func (rr RecoveryReport) String() string {
+
+	if rr.Blocked != "" {
+		return fmt.Sprintf("%s does not heal: %s\n", rr.Target.Name, rr.Blocked)
+	}
+
+	if len(rr.Healed) == 0 {
+		return fmt.Sprintf("%s has nothing to heal\n", rr.Target.Name)
+	}
+
+	text := fmt.Sprintf("%s recovers\n", rr.Target.Name)
+
+	for _, h := range rr.Healed {
+		text += fmt.Sprintf("-- %s\n", h)
+	}
+
+	if len(rr.Restored) > 0 {
+		text += fmt.Sprintf("Restored: %s\n", strings.Join(rr.Restored, ", "))
+	}
+	return text
+}
+
+// HasIntrinsic reports whether the Character's Archetype has the named Intrinsic
+func (c *Character) HasIntrinsic(name string) bool {
+
+	if c.Archetype == nil {
+		return false
+	}
+
+	for _, i := range c.Archetype.Intrinsics {
+		if strings.EqualFold(i.Name, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// Rest heals the Character naturally over elapsed in-game time using the
+// HealingRules for their Setting. Every location heals one Shock box per
+// ShockEvery and one Killing box per KillEvery, and time short of a full
+// period is kept toward the next box. Characters with the Unhealing
+// Intrinsic do not heal naturally.
+func (c *Character) Rest(elapsed time.Duration) (*RecoveryReport, error) {
+
+	rules, ok := Healing[c.Setting]
+	if !ok {
+		return nil, fmt.Errorf("no healing rules for setting %q", c.Setting)
+	}
+
+	rr := &RecoveryReport{Target: c}
+
+	if c.HasIntrinsic("Unhealing") {
+		rr.Blocked = "Unhealing"
+		return rr, nil
+	}
+
+	if elapsed <= 0 {
+		return rr, nil
+	}
+
+	// Shock heals before Killing, so Killing eased to Shock waits a period
+	c.recover(rr, func(l *Location) Healed {
+
+		h := Healed{Location: l.Name}
+
+		k, sh := l.CountWounds()
+
+		// Time only counts toward a box while one is marked
+		if sh > 0 {
+			l.ShockRest += elapsed
+		}
+		for rules.ShockEvery > 0 && l.ShockRest >= rules.ShockEvery {
+			l.ShockRest -= rules.ShockEvery
+			if !l.clearBox(l.Shock) {
+				l.ShockRest = 0
+				break
+			}
+			h.Shock++
+		}
+
+		if k > 0 {
+			l.KillRest += elapsed
+		}
+		for rules.KillEvery > 0 && l.KillRest >= rules.KillEvery {
+			l.KillRest -= rules.KillEvery
+			if !l.healKill(rules.KillToShock, &h) {
+				l.KillRest = 0
+			}
+		}
+
+		// Rest left over once a kind of box is clear is not banked
+		if k, sh = l.CountWounds(); k == 0 {
+			l.KillRest = 0
+		}
+		if sh == 0 {
+			l.ShockRest = 0
+		}
+		return h
+	})
+
+	return rr, nil
+}
+
+// FirstAid clears Shock at the named location, one box per point of width
+// of the best match in r, such as a Mind+First Aid roll.
+func (c *Character) FirstAid(location string, r *Roll) (*RecoveryReport, error) {
+	return c.treat(location, r, func(l *Location, h *Healed) bool {
+		if !l.clearBox(l.Shock) {
+			return false
+		}
+		h.Shock++
+		return true
+	})
+}
+
+// Medicine eases Killing at the named location into Shock, one box per
+// point of width of the best match in r, such as a Mind+Medicine roll.
+func (c *Character) Medicine(location string, r *Roll) (*RecoveryReport, error) {
+	return c.treat(location, r, func(l *Location, h *Healed) bool {
+		return l.healKill(true, h)
+	})
+}
+
+// treat heals a location once per point of width of r's best match
+func (c *Character) treat(location string, r *Roll, heal func(*Location, *Healed) bool) (*RecoveryReport, error) {
+
+	l := c.HitLocations[location]
+	if l == nil {
+		return nil, fmt.Errorf("%s has no hit location %q", c.Name, location)
+	}
+
+	if r == nil {
+		return nil, errors.New("no treatment roll")
+	}
+
+	rr := &RecoveryReport{Target: c}
+
+	m, ok := bestMatch(r)
+	if !ok {
+		rr.Blocked = "treatment failed"
+		return rr, nil
+	}
+
+	c.recover(rr, func(tl *Location) Healed {
+
+		h := Healed{Location: tl.Name}
+		if tl != l {
+			return h
+		}
+
+		for i := 0; i < m.Width; i++ {
+			if !heal(tl, &h) {
+				break
+			}
+		}
+		return h
+	})
+
+	return rr, nil
+}
+
+// recover applies heal to every location and records what changed
+func (c *Character) recover(rr *RecoveryReport, heal func(*Location) Healed) {
+
+	for _, name := range c.LocationMap {
+		l := c.HitLocations[name]
+		if l == nil {
+			continue
+		}
+
+		l.padWounds()
+
+		h := heal(l)
+		if h != (Healed{Location: l.Name}) {
+			rr.Healed = append(rr.Healed, h)
+		}
+
+		if l.Disabled && !l.Full() {
+			l.Disabled = false
+			rr.Restored = append(rr.Restored, l.Name)
+		}
+	}
+}
+
+// healKill clears the last Killing box, or turns it to Shock if toShock
+func (l *Location) healKill(toShock bool, h *Healed) bool {
+
+	for i := len(l.Kill) - 1; i >= 0; i-- {
+		if l.Kill[i] {
+			l.Kill[i] = false
+			if toShock {
+				l.Shock[i] = true
+				h.Converted++
+			} else {
+				h.Killing++
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// clearBox empties the last marked box in boxes
+func (l *Location) clearBox(boxes []bool) bool {
+
+	for i := len(boxes) - 1; i >= 0; i-- {
+		if boxes[i] {
+			boxes[i] = false
+			return true
+		}
+	}
+	return false
+}
@@ -1,6 +1,9 @@
 package oneroll
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 // Location represents a body area that can take damage
 type Location struct {
@@ -12,6 +15,11 @@ type Location struct {
 	LAR      int
 	HAR      int
 	Disabled bool
+
+	// Rest banked toward healing the next box, kept only while a box
+	// of that kind is marked
+	ShockRest time.Duration
+	KillRest  time.Duration
 }
 
 // Strings
@@ -67,3 +75,63 @@ func (l *Location) CountWounds() (int, int) {
 	}
 	return kill, shock
 }
+
+// LocationAt returns the Character's Location hit by a die of height h
+func (c *Character) LocationAt(h int) *Location {
+
+	for _, name := range c.LocationMap {
+		l := c.HitLocations[name]
+		if l == nil {
+			continue
+		}
+		for _, hl := range l.HitLoc {
+			if hl == h {
+				return l
+			}
+		}
+	}
+	return nil
+}
+
+// padWounds makes sure there is a Shock and Kill entry for every box
+func (l *Location) padWounds() {
+	for len(l.Kill) < l.Boxes {
+		l.Kill = append(l.Kill, false)
+	}
+	for len(l.Shock) < l.Boxes {
+		l.Shock = append(l.Shock, false)
+	}
+}
+
+// markEmpty fills the first empty box with Killing or Shock
+func (l *Location) markEmpty(kill bool) bool {
+	for i := 0; i < l.Boxes; i++ {
+		if !l.Kill[i] && !l.Shock[i] {
+			if kill {
+				l.Kill[i] = true
+			} else {
+				l.Shock[i] = true
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// convertShock turns the first Shock box into Killing
+func (l *Location) convertShock() bool {
+	for i := 0; i < l.Boxes; i++ {
+		if l.Shock[i] {
+			l.Shock[i] = false
+			l.Kill[i] = true
+			return true
+		}
+	}
+	return false
+}
+
+// Full returns true when every box holds Shock or Killing
+func (l *Location) Full() bool {
+	kill, shock := l.CountWounds()
+	return l.Boxes > 0 && kill+shock >= l.Boxes
+}
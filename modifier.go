@@ -26,10 +26,10 @@ func (m Modifier) String() string {
 		text += fmt.Sprintf(" - %s", m.Info)
 	}
 
-	if m.Cost > 0 {
-		text += fmt.Sprintf(" (+%d/die)", m.Cost)
+	if c := m.Price(); c > 0 {
+		text += fmt.Sprintf(" (+%d/die)", c)
 	} else {
-		text += fmt.Sprintf(" (%d/die)", m.Cost)
+		text += fmt.Sprintf(" (%d/die)", c)
 	}
 	return text
 }
@@ -50,16 +50,19 @@ func NewModifier(s string) *Modifier {
 	return m
 }
 
+// Price returns the Modifier's cost per die, CostPerLevel times Level
+// for Modifiers w/ levels
+func (m Modifier) Price() int {
+	if m.RequiresLevel {
+		return m.CostPerLevel * m.Level
+	}
+	return m.CostPerLevel
+}
+
 // CalculateCost updates the cost per level for Modifiers w/ levels
 // Called from Power.PowerCost()
 func (m *Modifier) CalculateCost(b int) {
-
-	if m.RequiresLevel {
-		b = m.CostPerLevel * m.Level
-	} else {
-		b = m.CostPerLevel
-	}
-	m.Cost = b
+	m.Cost = m.Price()
 }
 
 // Modifiers creates map of standard WT extras & Flaws
@@ -0,0 +1,328 @@
+package oneroll
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// RollSpec is the parsed form of a die string like 2ac+5d+1hd+1wd+1gf
+type RollSpec struct {
+	DiePool  *DiePool
+	Actions  int
+	NumRolls int
+}
+
+// NotationError reports the token and column where a die or damage
+// string failed to parse
+type NotationError struct {
+	Notation string // "die" or "damage", empty means die
+	Input    string
+	Column   int // 1-based
+	Token    string
+	Msg      string
+}
+
+func (e *NotationError) Error() string {
+
+	n := e.Notation
+	if n == "" {
+		n = "die"
+	}
+
+	if e.Token == "" {
+		return fmt.Sprintf("%s notation %q: %s at column %d", n, e.Input, e.Msg, e.Column)
+	}
+	return fmt.Sprintf("%s notation %q: %s at column %d (%q)", n, e.Input, e.Msg, e.Column, e.Token)
+}
+
+// maxCount is the largest count a single term may give. Pools are capped
+// at 10 dice after parsing, so no valid roll needs more.
+const maxCount = 100
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokNumber
+	tokWord
+	tokPlus
+	tokMinus
+	tokComma
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	col  int
+}
+
+// tokenize splits a die or damage string into numbers, lower-cased words,
+// and + - , separators. Whitespace is ignored.
+func tokenize(input string) ([]token, error) {
+
+	var tokens []token
+
+	runes := []rune(input)
+
+	for i := 0; i < len(runes); {
+		c := runes[i]
+
+		switch {
+		case unicode.IsSpace(c):
+			i++
+
+		case c == '+':
+			tokens = append(tokens, token{kind: tokPlus, text: "+", col: i + 1})
+			i++
+
+		case c == '-':
+			tokens = append(tokens, token{kind: tokMinus, text: "-", col: i + 1})
+			i++
+
+		case c == ',':
+			tokens = append(tokens, token{kind: tokComma, text: ",", col: i + 1})
+			i++
+
+		case unicode.IsDigit(c):
+			start := i
+			for i < len(runes) && unicode.IsDigit(runes[i]) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokNumber, text: string(runes[start:i]), col: start + 1})
+
+		case unicode.IsLetter(c):
+			start := i
+			for i < len(runes) && unicode.IsLetter(runes[i]) {
+				i++
+			}
+			tokens = append(tokens, token{
+				kind: tokWord,
+				text: strings.ToLower(string(runes[start:i])),
+				col:  start + 1,
+			})
+
+		default:
+			return nil, &NotationError{
+				Input:  input,
+				Column: i + 1,
+				Token:  string(c),
+				Msg:    "unexpected character",
+			}
+		}
+	}
+
+	tokens = append(tokens, token{kind: tokEOF, col: len(runes) + 1})
+
+	return tokens, nil
+}
+
+// notationParser walks the token stream for ParseDieNotation
+type notationParser struct {
+	input  string
+	tokens []token
+	pos    int
+	seen   map[string]bool
+
+	// poolOnly rejects the ac and nr options, which belong to a roll
+	poolOnly bool
+
+	// notation names the language in errors, empty means die
+	notation string
+}
+
+func (p *notationParser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *notationParser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *notationParser) errorAt(t token, msg string) error {
+	return &NotationError{
+		Notation: p.notation,
+		Input:    p.input,
+		Column:   t.col,
+		Token:    t.text,
+		Msg:      msg,
+	}
+}
+
+// ParseDieNotation parses a die string into a RollSpec.
+// The grammar is a + separated list of terms, each a count followed by a
+// suffix: d (normal), hd (hard), wd (wiggle), ed (expert), gf (go first),
+// sp (spray), ac (actions) and nr (number of rolls). An expert term may be
+// followed by the face it is set to, as in 1ed7, and may be repeated. Case and whitespace are
+// ignored, so "5d + 1HD" equals "5d+1hd".
+func ParseDieNotation(input string) (*RollSpec, error) {
+	return parseNotation(input, false)
+}
+
+// ParseDiePool parses the canonical form written by DiePool.String, so
+// that ParseDiePool(d.String()) reproduces d. Actions and number of
+// rolls are rejected as they are not part of a DiePool.
+func ParseDiePool(input string) (*DiePool, error) {
+
+	spec, err := parseNotation(input, true)
+	if err != nil {
+		return nil, err
+	}
+	return spec.DiePool, nil
+}
+
+func parseNotation(input string, poolOnly bool) (*RollSpec, error) {
+
+	tokens, err := tokenize(input)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &notationParser{
+		input:    input,
+		tokens:   tokens,
+		seen:     map[string]bool{},
+		poolOnly: poolOnly,
+	}
+
+	spec := &RollSpec{
+		DiePool:  &DiePool{},
+		Actions:  1,
+		NumRolls: 1,
+	}
+
+	if p.peek().kind == tokEOF {
+		return nil, p.errorAt(p.peek(), "empty die notation")
+	}
+
+	for {
+		if err := p.parseTerm(spec); err != nil {
+			return nil, err
+		}
+
+		t := p.next()
+
+		switch t.kind {
+		case tokEOF:
+			// Ensure at least one action and one roll
+			if spec.Actions < 1 {
+				spec.Actions = 1
+			}
+			if spec.NumRolls < 1 {
+				spec.NumRolls = 1
+			}
+			return spec, nil
+		case tokPlus:
+			continue
+		default:
+			return nil, p.errorAt(t, "expected + between terms")
+		}
+	}
+}
+
+// parseTerm reads a single count+suffix term into spec
+func (p *notationParser) parseTerm(spec *RollSpec) error {
+
+	numTok := p.next()
+	if numTok.kind != tokNumber {
+		return p.errorAt(numTok, "expected a number")
+	}
+
+	n, err := strconv.Atoi(numTok.text)
+	if err != nil || n > maxCount {
+		return p.errorAt(numTok, fmt.Sprintf("number must be no more than %d", maxCount))
+	}
+
+	suffix := p.next()
+	if suffix.kind != tokWord {
+		return p.errorAt(suffix, "expected a die type or option after number")
+	}
+
+	if p.poolOnly && (suffix.text == "ac" || suffix.text == "nr") {
+		return p.errorAt(suffix, "actions and rolls are not part of a die pool")
+	}
+
+	// Options may only appear once, dice groups accumulate
+	switch suffix.text {
+	case "gf", "sp", "ac", "nr":
+		if p.seen[suffix.text] {
+			return p.errorAt(suffix, "option given more than once")
+		}
+		p.seen[suffix.text] = true
+	}
+
+	d := spec.DiePool
+
+	switch suffix.text {
+	case "d":
+		d.Normal += n
+	case "hd":
+		d.Hard += n
+	case "wd":
+		d.Wiggle += n
+	case "ed":
+		return p.parseExpert(numTok, n, d)
+	case "gf":
+		d.GoFirst = n
+	case "sp":
+		d.Spray = n
+	case "ac":
+		spec.Actions = n
+	case "nr":
+		spec.NumRolls = n
+	default:
+		return p.errorAt(suffix, "unknown die type or option")
+	}
+	return nil
+}
+
+// parseExpert handles both the legacy "7ed" form, where the number is the
+// face of a single expert die, and the "2ed7" count+face form.
+func (p *notationParser) parseExpert(numTok token, n int, d *DiePool) error {
+
+	if p.peek().kind != tokNumber {
+		if n > 10 {
+			return p.errorAt(numTok, "expert die face must be between 1 and 10")
+		}
+		if n > 0 {
+			d.Expert = append(d.Expert, n)
+		}
+		return nil
+	}
+
+	faceTok := p.next()
+	face, err := strconv.Atoi(faceTok.text)
+	if err != nil || face < 1 || face > 10 {
+		return p.errorAt(faceTok, "expert die face must be between 1 and 10")
+	}
+
+	for i := 0; i < n; i++ {
+		d.Expert = append(d.Expert, face)
+	}
+	return nil
+}
+
+// String writes a RollSpec in canonical notation, omitting the
+// default of one action and one roll
+func (s RollSpec) String() string {
+
+	text := "0d"
+	if s.DiePool != nil {
+		text = s.DiePool.String()
+	}
+
+	if s.Actions > 1 {
+		text += fmt.Sprintf("+%dac", s.Actions)
+	}
+
+	if s.NumRolls > 1 {
+		text += fmt.Sprintf("+%dnr", s.NumRolls)
+	}
+
+	return text
+}
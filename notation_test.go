@@ -0,0 +1,107 @@
+package oneroll
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseDieNotation(t *testing.T) {
+
+	tests := []struct {
+		input    string
+		pool     DiePool
+		actions  int
+		numRolls int
+	}{
+		{"4d", DiePool{Normal: 4}, 1, 1},
+		{"5d + 1HD", DiePool{Normal: 5, Hard: 1}, 1, 1},
+		{"2ac+5d+1hd+1wd+1gf", DiePool{Normal: 5, Hard: 1, Wiggle: 1, GoFirst: 1}, 2, 1},
+		{"3d+2d", DiePool{Normal: 5}, 1, 1},
+		{"4d+7ed", DiePool{Normal: 4, Expert: []int{7}}, 1, 1},
+		{"2ed7+1ed9", DiePool{Expert: []int{7, 7, 9}}, 1, 1},
+		{"6d+2sp+3nr", DiePool{Normal: 6, Spray: 2}, 1, 3},
+		{"0d+0ac", DiePool{}, 1, 1},
+	}
+
+	for _, tt := range tests {
+		spec, err := ParseDieNotation(tt.input)
+		if err != nil {
+			t.Errorf("ParseDieNotation(%q): %v", tt.input, err)
+			continue
+		}
+		if !reflect.DeepEqual(*spec.DiePool, tt.pool) {
+			t.Errorf("ParseDieNotation(%q) pool = %+v, want %+v", tt.input, *spec.DiePool, tt.pool)
+		}
+		if spec.Actions != tt.actions || spec.NumRolls != tt.numRolls {
+			t.Errorf("ParseDieNotation(%q) = %dac %dnr, want %dac %dnr",
+				tt.input, spec.Actions, spec.NumRolls, tt.actions, tt.numRolls)
+		}
+	}
+}
+
+func TestParseDieNotationErrors(t *testing.T) {
+
+	tests := []struct {
+		input  string
+		column int
+		token  string
+	}{
+		{"", 1, ""},
+		{"4d+", 4, ""},
+		{"4x", 2, "x"},
+		{"d", 1, "d"},
+		{"4d 2d", 4, "2"},
+		{"1gf+1gf", 6, "gf"},
+		{"4d+1ed11", 7, "11"},
+		{"4d+11ed", 4, "11"},
+		{"100000000ed7", 1, "100000000"},
+		{"4d#", 3, "#"},
+	}
+
+	for _, tt := range tests {
+		_, err := ParseDieNotation(tt.input)
+
+		ne, ok := err.(*NotationError)
+		if !ok {
+			t.Errorf("ParseDieNotation(%q) error = %v, want a NotationError", tt.input, err)
+			continue
+		}
+		if ne.Column != tt.column || ne.Token != tt.token {
+			t.Errorf("ParseDieNotation(%q) error at column %d (%q), want column %d (%q)",
+				tt.input, ne.Column, ne.Token, tt.column, tt.token)
+		}
+	}
+}
+
+func TestDiePoolRoundTrip(t *testing.T) {
+
+	// Expert faces are listed lowest first, the order String writes them
+	pools := []DiePool{
+		{},
+		{Normal: 4},
+		{Hard: 2, Wiggle: 1},
+		{Normal: 4, Hard: 2, Expert: []int{7, 9}, Wiggle: 1, GoFirst: 1, Spray: 2},
+		{Expert: []int{3, 3, 3, 10}},
+		{Normal: 10, GoFirst: 3},
+	}
+
+	for _, d := range pools {
+		s := d.String()
+
+		got, err := ParseDiePool(s)
+		if err != nil {
+			t.Errorf("ParseDiePool(%q): %v", s, err)
+			continue
+		}
+		if !reflect.DeepEqual(*got, d) {
+			t.Errorf("ParseDiePool(%q) = %+v, want %+v", s, *got, d)
+		}
+		if got.String() != s {
+			t.Errorf("ParseDiePool(%q).String() = %q", s, got.String())
+		}
+	}
+
+	if _, err := ParseDiePool("4d+2ac"); err == nil {
+		t.Error("ParseDiePool accepted actions")
+	}
+}
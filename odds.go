@@ -0,0 +1,198 @@
+package oneroll
+
+import (
+	"fmt"
+	"math"
+)
+
+// Odds is the exact distribution of results for a DiePool.
+// Widths, heights and set counts are indexed by value, with index 0
+// holding the chance of rolling no match at all.
+type Odds struct {
+	DiePool *DiePool    // Pool actually rolled after actions, spray, penalties and the 10 die cap
+	Width   [11]float64 // Width of the best match
+	Height  [11]float64 // Height of the best match
+	Sets    [6]float64  // Number of matches rolled
+
+	// meets[w][h] is the chance of any match at least w wide and h high
+	meets      [11][11]float64
+	difficulty int
+	bonus      int // width added to the best match by OverflowBonusWidth
+}
+
+// CalculateOdds enumerates every possible roll of d to give exact odds.
+// The pool is adjusted for actions, spray, penalty dice, the 10 die cap
+// and any called shot exactly as Roll.Resolve does, and matches below
+// opts.Difficulty are not counted. Wiggle dice are assigned with opts.Wiggle, or
+// WiggleForWidth if none is set. The best match is the widest, then the
+// highest, as sorted by ByWidthHeight. An error is returned if the called
+// shot cannot be made.
+func CalculateOdds(d *DiePool, opts RollOptions) (*Odds, error) {
+
+	td := d.clone()
+
+	o := &Odds{DiePool: td, difficulty: opts.Difficulty}
+
+	reduceForActions(td, Max(opts.Actions, 1), ignoreAdjustments)
+	removeDice(td, opts.Penalty, ignoreAdjustments(""))
+
+	if opts.Overflow == OverflowBonusWidth {
+		o.bonus = Max(SumDice(td)-10, 0)
+	}
+	capPool(td, ignoreAdjustments(""))
+
+	if opts.CalledShot != nil {
+		cs, err := opts.CalledShot.resolve()
+		if err != nil {
+			return nil, err
+		}
+		if err := callShot(td, cs.Height, ignoreAdjustments("")); err != nil {
+			return nil, err
+		}
+	}
+
+	wiggle := opts.Wiggle
+	if wiggle == nil {
+		wiggle = WiggleForWidth
+	}
+
+	var fixed []int
+	for i := 0; i < td.Hard; i++ {
+		fixed = append(fixed, 10)
+	}
+	fixed = append(fixed, td.Expert...)
+
+	counts := make([]int, 11)
+	base := math.Pow(0.1, float64(td.Normal))
+
+	// Walk every multiset of normal dice faces, weighting each by the
+	// number of orderings that produce it
+	var walk func(face, left int, weight float64)
+	walk = func(face, left int, weight float64) {
+
+		if face == 10 {
+			counts[10] = left
+			o.add(counts, fixed, td.Wiggle, wiggle,
+				base*weight/factorial(left))
+			return
+		}
+
+		for n := 0; n <= left; n++ {
+			counts[face] = n
+			walk(face+1, left-n, weight/factorial(n))
+		}
+	}
+
+	walk(1, td.Normal, factorial(td.Normal))
+
+	return o, nil
+}
+
+// add records a single outcome of normal dice counts with probability p
+func (o *Odds) add(counts, fixed []int, wiggles int, strategy WiggleStrategy, p float64) {
+
+	results := append([]int{}, fixed...)
+	for face := 1; face <= 10; face++ {
+		for i := 0; i < counts[face]; i++ {
+			results = append(results, face)
+		}
+	}
+
+	if wiggles > 0 {
+		results = append(results, strategy(results, wiggles)...)
+	}
+
+	faces := make([]int, 11)
+	for _, f := range results {
+		faces[f]++
+	}
+
+	bestWidth, bestHeight, sets := 0, 0, 0
+
+	for h := 1; h <= 10; h++ {
+		if faces[h] >= 2 && h >= o.difficulty && faces[h] >= bestWidth {
+			bestWidth, bestHeight = faces[h], h
+		}
+	}
+
+	// Widths beyond 10 are counted as 10
+	if bestWidth > 0 && o.bonus > 0 {
+		bestWidth = Min(bestWidth+o.bonus, 10)
+		faces[bestHeight] = bestWidth
+	}
+
+	for h := 1; h <= 10; h++ {
+		w := faces[h]
+		if w < 2 || h < o.difficulty {
+			continue
+		}
+
+		sets++
+
+		for mw := 2; mw <= w; mw++ {
+			for mh := 1; mh <= h; mh++ {
+				if !o.matched(faces, mw, mh, h) {
+					o.meets[mw][mh] += p
+				}
+			}
+		}
+	}
+
+	o.Width[bestWidth] += p
+	o.Height[bestHeight] += p
+	o.Sets[sets] += p
+}
+
+// matched reports whether a match lower than h already met width w and height mh,
+// so that each outcome is counted once in meets
+func (o *Odds) matched(faces []int, w, mh, h int) bool {
+	for lower := Max(mh, o.difficulty); lower < h; lower++ {
+		if faces[lower] >= w {
+			return true
+		}
+	}
+	return false
+}
+
+// AtLeast returns the chance of rolling any match at least width wide and
+// height high. AtLeast(2, 1) is the chance of any match at all.
+func (o *Odds) AtLeast(width, height int) float64 {
+
+	width = Max(width, 2)
+	height = Max(height, 1)
+
+	if width > 10 || height > 10 {
+		return 0
+	}
+	return o.meets[width][height]
+}
+
+func (o Odds) String() string {
+
+	text := fmt.Sprintf("Odds for %s\n", o.DiePool)
+
+	text += fmt.Sprintf("No match: %.2f%%\n", o.Width[0]*100)
+
+	for w := 2; w <= 10; w++ {
+		if o.Width[w] > 0 {
+			text += fmt.Sprintf("Best width %d: %.2f%% (%dx or better: %.2f%%)\n",
+				w, o.Width[w]*100, w, o.AtLeast(w, 1)*100)
+		}
+	}
+
+	for h := 1; h <= 10; h++ {
+		if o.Height[h] > 0 {
+			text += fmt.Sprintf("Best height %d: %.2f%%\n", h, o.Height[h]*100)
+		}
+	}
+
+	return text
+}
+
+func factorial(n int) float64 {
+	f := 1.0
+	for i := 2; i <= n; i++ {
+		f *= float64(i)
+	}
+	return f
+}
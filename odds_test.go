@@ -0,0 +1,122 @@
+package oneroll
+
+import (
+	"math"
+	"testing"
+)
+
+func sum(p []float64) float64 {
+	var t float64
+	for _, v := range p {
+		t += v
+	}
+	return t
+}
+
+func TestCalculateOddsSumsToOne(t *testing.T) {
+
+	tests := []struct {
+		pool DiePool
+		opts RollOptions
+	}{
+		{DiePool{Normal: 2}, RollOptions{}},
+		{DiePool{Normal: 6}, RollOptions{}},
+		{DiePool{Normal: 10}, RollOptions{}},
+		{DiePool{Normal: 4, Hard: 1, Expert: []int{7}}, RollOptions{}},
+		{DiePool{Normal: 5, Wiggle: 2}, RollOptions{}},
+		{DiePool{Normal: 8}, RollOptions{Actions: 2, Difficulty: 5}},
+		{DiePool{Normal: 12, Hard: 2}, RollOptions{Overflow: OverflowBonusWidth}},
+		{DiePool{Normal: 6}, RollOptions{CalledShot: &CalledShot{Height: 10}}},
+	}
+
+	for _, tt := range tests {
+		o, err := CalculateOdds(&tt.pool, tt.opts)
+		if err != nil {
+			t.Errorf("CalculateOdds(%s): %v", tt.pool, err)
+			continue
+		}
+
+		for name, p := range map[string][]float64{
+			"Width":  o.Width[:],
+			"Height": o.Height[:],
+			"Sets":   o.Sets[:],
+		} {
+			if s := sum(p); math.Abs(s-1) > 1e-9 {
+				t.Errorf("CalculateOdds(%s) %s sums to %v", tt.pool, name, s)
+			}
+		}
+	}
+}
+
+func TestCalculateOddsExact(t *testing.T) {
+
+	o, err := CalculateOdds(&DiePool{Normal: 2}, RollOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if math.Abs(o.Width[2]-0.1) > 1e-9 || math.Abs(o.Height[7]-0.01) > 1e-9 {
+		t.Errorf("2d: Width[2] = %v, Height[7] = %v, want 0.1 and 0.01", o.Width[2], o.Height[7])
+	}
+
+	// Five dice all different: 10*9*8*7*6 of 10^5 rolls
+	o, err = CalculateOdds(&DiePool{Normal: 5}, RollOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(o.Width[0]-0.3024) > 1e-9 {
+		t.Errorf("5d: Width[0] = %v, want 0.3024", o.Width[0])
+	}
+}
+
+func TestCalculateOddsMatchesRolls(t *testing.T) {
+
+	const rolls = 20000
+
+	d := &DiePool{Normal: 6}
+
+	o, err := CalculateOdds(d, RollOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	src := NewSeededSource(1)
+
+	var width [11]float64
+	for i := 0; i < rolls; i++ {
+		r := &Roll{}
+		if _, err := r.ResolvePool(d, RollOptions{Source: src}); err != nil {
+			t.Fatal(err)
+		}
+
+		w := 0
+		for _, m := range r.Matches {
+			w = Max(w, m.Width)
+		}
+		width[w]++
+	}
+
+	for w := range width {
+		if got := width[w] / rolls; math.Abs(got-o.Width[w]) > 0.015 {
+			t.Errorf("Width[%d]: rolled %v, odds give %v", w, got, o.Width[w])
+		}
+	}
+}
+
+func TestCalculateOddsCalledShotError(t *testing.T) {
+
+	c := NewWTCharacter("Target")
+	cs := &CalledShot{Target: c, Location: "tail"}
+
+	if _, err := CalculateOdds(&DiePool{Normal: 4}, RollOptions{CalledShot: cs}); err == nil {
+		t.Error("CalculateOdds accepted a called shot at a missing location")
+	}
+
+	cs = &CalledShot{Target: c, Location: "head"}
+	if _, err := CalculateOdds(&DiePool{Normal: 4}, RollOptions{CalledShot: cs}); err != nil {
+		t.Fatal(err)
+	}
+	if cs.Location != "head" || cs.Height != 0 {
+		t.Errorf("CalculateOdds changed the caller's CalledShot to %+v", *cs)
+	}
+}
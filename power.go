@@ -38,42 +38,66 @@ func (p Power) String() string {
 	return text
 }
 
-// CalculateCost totals the cost of Qualites for a Power
-func (p *Power) CalculateCost() {
+// getDiePool returns the Power's dice with Go First and Spray
+// taken from its Qualities' modifiers
+func (p *Power) getDiePool() *DiePool {
 
-	b := 0
+	td := &DiePool{}
+	if p.Dice != nil {
+		td = p.Dice.clone()
+	}
 
 	for _, q := range p.Qualities {
+		for _, m := range q.Modifiers {
+			if m.Name == "Spray" {
+				td.Spray = Max(td.Spray, m.Level)
+			}
 
-		// Update Quality DiePool if needed
-		if q.Dice == nil {
-			q.Dice = p.Dice
+			if m.Name == "Go First" {
+				td.GoFirst = Max(td.GoFirst, m.Level)
+			}
 		}
+	}
+	return td
+}
+
+// CalculateCost totals the cost of Qualites for a Power
+func (p *Power) CalculateCost() int {
+	return p.CalculateCostFor(Settings["WT"])
+}
 
-		// Add Power Capacity Modifier if needed
-		// Depreciated - must now be done manually
+// CalculateCostFor stores and returns the Power's cost using cf.
+// Power Capacity Modifiers must be added to Qualities manually.
+func (p *Power) CalculateCostFor(cf CostFramework) int {
 
-		for _, m := range q.Modifiers {
-			m.CalculateCost(0)
-		}
-		q.CalculateCost(2)
-		if q.CostPerDie < 1 {
-			// minimum cost of 1/die per Quality in a Power
-			q.CostPerDie = 1
-		}
-		b += q.CostPerDie
+	ci := p.ExplainCost(cf)
+	per := ci.Items[0]
+
+	// Cost per die lists each Quality
+	for i, q := range p.Qualities {
+		q.CostPerDie = per.Items[i].Cost
 	}
 
-	p.CostPerDie = b
+	p.CostPerDie = per.Cost
+	p.Cost = ci.Cost
 
-	total := b * p.Dice.Normal
-	total += b * 2 * p.Dice.Hard
-	total += b * 4 * p.Dice.Wiggle
+	return p.Cost
+}
+
+// PriceFor returns the Power's cost using cf without changing it
+func (p *Power) PriceFor(cf CostFramework) int {
+	return p.ExplainCost(cf).Cost
+}
 
-	p.Cost = total
+// FormatQualityDiePool returns a die string for one of the Power's
+// Qualities, using the Power's dice if the Quality has none of its own
+func (p *Power) FormatQualityDiePool(q *Quality, actions int) string {
 
-	// Update slug while we're at it
-	p.Slug = ToSnakeCase(p.Name)
+	tq := *q
+	if tq.Dice == nil {
+		tq.Dice = p.Dice
+	}
+	return tq.FormatDiePool(actions)
 }
 
 // DeterminePowerCapacities calculates string values for powers
@@ -154,6 +178,7 @@ func NewPower(t string) *Power {
 	p := new(Power)
 
 	p.Name = t
+	p.Slug = ToSnakeCase(t)
 	p.Effect = ""
 	p.Qualities = []*Quality{}
 	p.Dice = &DiePool{}
@@ -57,28 +57,58 @@ func (q Quality) String() string {
 	return text
 }
 
-// FormatDiePool returns a die string
+// AreaDice returns the area dice from the Quality's Area modifier
+func (q *Quality) AreaDice() int {
+	for _, m := range q.Modifiers {
+		if m.Name == "Area" {
+			return m.Level
+		}
+	}
+	return 0
+}
+
+// Radius returns the Quality's area in metres, 10m doubled for each
+// level of Radius beyond the first. An Area Quality without Radius
+// covers 10m.
+func (q *Quality) Radius() int {
+
+	radius := 0
+	for _, m := range q.Modifiers {
+		if m.Name == "Radius" && m.Level > 0 {
+			radius = 10 << uint(m.Level-1)
+		}
+	}
+
+	if radius == 0 && q.AreaDice() > 0 {
+		radius = 10
+	}
+	return radius
+}
+
+// FormatDiePool returns a die string in canonical notation
 func (q *Quality) FormatDiePool(actions int) string {
 
+	td := &DiePool{}
+	if q.Dice != nil {
+		td = q.Dice.clone()
+	}
+
 	for _, m := range q.Modifiers {
 		if m.Name == "Spray" {
-			q.Dice.Spray = m.Level
+			td.Spray = m.Level
 		}
 
 		if m.Name == "Go First" {
-			q.Dice.GoFirst = m.Level
+			td.GoFirst = m.Level
 		}
 	}
 
-	text := fmt.Sprintf("%dac+%dd+%dhd+%dwd+%dgf+%dsp",
-		actions,
-		q.Dice.Normal,
-		q.Dice.Hard,
-		q.Dice.Wiggle,
-		q.Dice.GoFirst,
-		q.Dice.Spray)
+	spec := RollSpec{
+		DiePool: td,
+		Actions: actions,
+	}
 
-	return text
+	return spec.String()
 }
 
 // NewQuality generates a new empty Quality
@@ -101,19 +131,31 @@ func NewQuality(t string) *Quality {
 // CalculateCost determines the cost of a Power Quality
 // Called from Power.PowerCost()
 func (q *Quality) CalculateCost(b int) {
+	q.CostPerDie = q.Price(b)
+}
+
+// Price returns the Quality's cost per die on top of base b, with any
+// implied Modifiers that are priced but not stored on the Quality
+func (q *Quality) Price(b int, implied ...Modifier) int {
+	return explainQuality(q, b, 0, "0/die", implied...).Cost
+}
+
+// impliedCapacity returns the Power Capacity Modifier needed for
+// Capacities beyond the free number, unless one was added by hand
+func (q *Quality) impliedCapacity(free int) []Modifier {
 
-	b += q.Level
+	if len(q.Capacities) <= free {
+		return nil
+	}
 
 	for _, m := range q.Modifiers {
-		if m.RequiresLevel {
-			b += m.CostPerLevel * m.Level
-		} else {
-			b += m.CostPerLevel
+		if m.Name == "Power Capacity" {
+			return nil
 		}
 	}
-	if b < 0 {
-		// No negative costs allowed
-		b = 0
-	}
-	q.CostPerDie = b
+
+	tm := Modifiers["Power Capacity"]
+	tm.Level = len(q.Capacities) - free
+
+	return []Modifier{tm}
 }
@@ -3,6 +3,7 @@ package oneroll
 import (
 	"fmt"
 	"sort"
+	"strings"
 )
 
 // DieStringFormatter is an interface to generate a die string
@@ -11,103 +12,148 @@ type formatter interface {
 	formatDiePool()
 }
 
-// OpposedRoll determines the results of an opposed roll between two or more actors
-func OpposedRoll(rolls ...*Roll) []Match {
+// OpposedEntry is one actor's part in an opposed roll
+type OpposedEntry struct {
+	Actor   *Character
+	Roll    *Roll
+	Best    Match // Widest match, highest breaking ties
+	Matched bool  // False if the roll produced no match
+	Sense   int   // Dice in the actor's Sense, used to break ties
+}
 
-	fmt.Println("Opposed Roll Resolution")
+// OpposedResult is the outcome of an opposed roll. Entries are ordered
+// from winner to loser. Winner is nil when nobody matched or the best
+// matches tie even after comparing Sense; Tied then lists who tied.
+type OpposedResult struct {
+	Entries     []OpposedEntry
+	Winner      *OpposedEntry
+	Tied        []*Character
+	SenseBroken bool    // Sense decided between equal matches
+	Order       []Match // Every match in initiative order
+}
 
-	var results []Match
+// Oppose compares rolls without printing anything. The widest match wins,
+// then the highest, then the actor with the most Sense dice.
+func Oppose(rolls ...*Roll) *OpposedResult {
+
+	res := &OpposedResult{Order: collectMatches(rolls...)}
 
 	for _, r := range rolls {
+		e := OpposedEntry{Actor: r.Actor, Roll: r}
+		e.Best, e.Matched = bestMatch(r)
+		if r.Actor != nil {
+			e.Sense = r.Actor.senseDice()
+		}
+		res.Entries = append(res.Entries, e)
+	}
 
-		fmt.Printf("Actor: %s, Action: %s, GoFirst: %d, Spray: %d, Wiggle Dice: %dwd\n",
-			r.Actor.Name,
-			r.Action,
-			r.DiePool.GoFirst,
-			r.DiePool.Spray,
-			r.Wiggles,
-		)
+	sort.SliceStable(res.Entries, func(i, j int) bool {
+		return res.Entries[i].beats(res.Entries[j], true)
+	})
 
-		for _, m := range r.Matches {
-			results = append(results, m)
-		}
-		sort.Sort(ByWidthHeight(results))
+	if len(res.Entries) == 0 || !res.Entries[0].Matched {
+		return res
 	}
-	return results
-}
 
-// PrintOpposed sorts actions by width and displays
-func PrintOpposed(results []Match) {
-	fmt.Println("***Resolution***")
+	top := res.Entries[0]
 
-	for i, m := range results {
-		fmt.Printf("***ACTION %d: Actor: %s, Match: %dx%d, Initiative: %dx%d\n",
-			i+1,
-			m.Actor.Name,
-			m.Height, m.Width,
-			m.Height, m.Initiative,
-		)
+	if len(res.Entries) > 1 {
+		next := res.Entries[1]
+
+		if next.Matched && !top.beats(next, false) {
+			if !top.beats(next, true) {
+				for _, e := range res.Entries {
+					if e.Matched && !top.beats(e, true) {
+						res.Tied = append(res.Tied, e.Actor)
+					}
+				}
+				return res
+			}
+			res.SenseBroken = true
+		}
 	}
-}
 
-// Resolve takes a slice of Rolls and determines outcomes
-// This should probably be part of a Combat function
-func Resolve(rolls ...*Roll) {
+	res.Winner = &res.Entries[0]
 
-	fmt.Println("Opposed Roll Resolution")
+	return res
+}
 
-	var results []Match
+// beats reports whether e ranks above o by width, then height, then
+// Sense if sense is true
+func (e OpposedEntry) beats(o OpposedEntry, sense bool) bool {
+
+	switch {
+	case e.Matched != o.Matched:
+		return e.Matched
+	case e.Best.Width != o.Best.Width:
+		return e.Best.Width > o.Best.Width
+	case e.Best.Height != o.Best.Height:
+		return e.Best.Height > o.Best.Height
+	case sense:
+		return e.Sense > o.Sense
+	}
+	return false
+}
 
-	// Initialize map to count actions
-	var actors map[string]int
-	var actions map[string][]string
+// senseDice counts the dice in the Character's Sense, 0 if they have none
+func (c *Character) senseDice() int {
 
-	for _, r := range rolls {
+	for k, s := range c.Statistics {
+		if strings.EqualFold(k, "Sense") && s.Dice != nil {
+			return SumDice(s.Dice)
+		}
+	}
+	return 0
+}
 
-		name := r.Actor.Name
+// IsTie reports whether the best matches tied
+func (res *OpposedResult) IsTie() bool {
+	return len(res.Tied) > 0
+}
 
-		// Declarations
+func (res OpposedResult) String() string {
 
-		// Sort by r.Actor.Sense
+	text := "***Opposed Roll***\n"
 
-		// Track number of actions per actor
-		actors[name] = r.NumActions
+	for _, e := range res.Entries {
+		name := "Unknown"
+		if e.Actor != nil {
+			name = e.Actor.Name
+		}
 
-		// Declare actions for each action taken
+		if e.Matched {
+			text += fmt.Sprintf("%s: %dx%d\n", name, e.Best.Width, e.Best.Height)
+		} else {
+			text += fmt.Sprintf("%s: no match\n", name)
+		}
+	}
 
-	ChooseAction:
-		for actors[r.Actor.Name] > 0 {
-			fmt.Printf("Declare action %d of %d for %s.",
-				actors[r.Actor.Name],
-				r.NumActions,
-				r.Actor.Name)
+	switch {
+	case res.Winner != nil && res.SenseBroken:
+		text += fmt.Sprintf("%s wins on Sense\n", res.Winner.Actor.Name)
+	case res.Winner != nil:
+		text += fmt.Sprintf("%s wins\n", res.Winner.Actor.Name)
+	case res.IsTie():
+		var names []string
+		for _, c := range res.Tied {
+			names = append(names, c.Name)
+		}
+		text += fmt.Sprintf("Tie between %s\n", strings.Join(names, ", "))
+	default:
+		text += "Nobody succeeds\n"
+	}
 
-			answer := UserQuery(`
-			1: Attack
-			2: Defend
-			3: Useful
+	return text
+}
 
-			Choice: `)
+// OpposedRoll determines the results of an opposed roll between two or more actors
+//
+// Deprecated: OpposedRoll prints to stdout; use Oppose
+func OpposedRoll(rolls ...*Roll) []Match {
 
-			if answer == "" {
-				fmt.Println("Exiting")
-				break ChooseAction
-			}
+	fmt.Println("Opposed Roll Resolution")
 
-			switch answer {
-			case "1":
-				actions[name] = append(actions[name], "attack")
-				actors[name]--
-			case "2":
-				actions[name] = append(actions[name], "defend")
-				actors[name]--
-			case "3":
-				actions[name] = append(actions[name], "useful")
-				actors[name]--
-			default:
-				fmt.Println("Not a valid option. Please choose again")
-			}
-		}
+	for _, r := range rolls {
 
 		fmt.Printf("Actor: %s, Action: %s, GoFirst: %d, Spray: %d, Wiggle Dice: %dwd\n",
 			r.Actor.Name,
@@ -116,16 +162,51 @@ func Resolve(rolls ...*Roll) {
 			r.DiePool.Spray,
 			r.Wiggles,
 		)
+	}
+	return collectMatches(rolls...)
+}
+
+// collectMatches gathers the Matches from all rolls in initiative order
+func collectMatches(rolls ...*Roll) []Match {
 
-		for _, m := range r.Matches {
-			results = append(results, m)
+	var results []Match
+
+	for _, r := range rolls {
+		results = append(results, r.Matches...)
+	}
+	sort.Sort(ByWidthHeight(results))
+
+	return results
+}
+
+// bestMatch returns a Roll's widest match, highest breaking ties
+func bestMatch(r *Roll) (Match, bool) {
+
+	var best Match
+	found := false
+
+	for _, m := range r.Matches {
+		if !found || m.Width > best.Width ||
+			(m.Width == best.Width && m.Height > best.Height) {
+			best = m
+			found = true
 		}
-		sort.Sort(ByWidthHeight(results))
 	}
+	return best, found
+}
+
+// PrintOpposed sorts actions by width and displays
+//
+// Deprecated: render an OpposedResult instead
+func PrintOpposed(results []Match) {
+	fmt.Println("***Resolution***")
 
-	//for _, m := range results {
-	// In initiative order, let actors allocate their Matches
-	// Attack = wound opponent and knock a die from their highest matche
-	// Defend = gobble attacks against the actor
-	// Useful = do something else
+	for i, m := range results {
+		fmt.Printf("***ACTION %d: Actor: %s, Match: %dx%d, Initiative: %dx%d\n",
+			i+1,
+			m.Actor.Name,
+			m.Height, m.Width,
+			m.Height, m.Initiative,
+		)
+	}
 }
@@ -4,23 +4,116 @@ import (
 	"errors"
 	"flag"
 	"fmt"
-	"regexp"
 	"sort"
-	"strconv"
 	"strings"
 )
 
 // Roll shows all results and variables from an ORE roll
 type Roll struct {
-	Actor      *Character
-	Action     string // type of action act, oppose, maneuver
-	NumActions int
-	DiePool    *DiePool
-	Results    []int
-	Matches    []Match
-	Loose      []int
-	Wiggles    int
-	Input      string
+	Actor        *Character
+	Action       string // type of action act, oppose, maneuver
+	NumActions   int
+	DiePool      *DiePool
+	Results      []int
+	Matches      []Match
+	Loose        []int
+	Wiggles      int
+	WiggleSet    []int // faces assigned to wiggle dice by AssignWiggles
+	RawResults   []int // Results before any wiggle dice were assigned
+	Input        string
+	Source       RandomSource   // nil uses a shared, time-seeded source
+	Difficulty   int            // minimum height for a match to succeed
+	Penalty      int            // dice removed from the pool before rolling
+	WoundPenalty int            // dice removed for the Actor's wounds
+	CalledShot   *CalledShot    // location targeted, if any
+	Below        []Match        // matches lower than Difficulty
+	Overflow     OverflowPolicy // what to do with dice beyond 10
+	BonusWidth   int            // width added to the best match by OverflowBonusWidth
+	Adjustments  []Adjustment   // every change made to DiePool before rolling
+}
+
+// OverflowPolicy decides what happens to a pool of more than 10 dice
+type OverflowPolicy int
+
+const (
+	// OverflowTruncate removes the excess dice
+	OverflowTruncate OverflowPolicy = iota
+	// OverflowError refuses to roll the pool
+	OverflowError
+	// OverflowBonusWidth removes the excess dice and adds one width
+	// to the best match for each, up to width 10
+	OverflowBonusWidth
+)
+
+// Adjustment records a change made to a DiePool before it was rolled
+type Adjustment struct {
+	DieType string // Normal, Hard, Expert or Wiggle
+	From    int
+	To      int
+	Reason  string
+}
+
+func (a Adjustment) String() string {
+	return fmt.Sprintf("%s dice %d to %d (%s)", a.DieType, a.From, a.To, a.Reason)
+}
+
+// RollOptions sets how a DiePool is rolled by ResolvePool
+type RollOptions struct {
+	Actions      int            // Number of actions declared, 0 is treated as 1
+	Wiggle       WiggleStrategy // Assigns wiggle dice after the roll if set
+	Difficulty   int            // Minimum height for a match to succeed
+	Penalty      int            // Dice removed from the pool before rolling
+	Overflow     OverflowPolicy // What to do with dice beyond 10
+	Source       RandomSource   // Replaces the Roll's Source if set
+	IgnoreWounds bool           // Skip the Actor's wound penalties and incapacitation
+	CalledShot   *CalledShot    // Sets a die to a chosen hit location
+}
+
+// CalledShot aims an attack at a hit location. Naming a Location sets the
+// die to the highest height that hits it on Target; otherwise Height is used.
+type CalledShot struct {
+	Target   *Character
+	Location string
+	Height   int
+}
+
+// resolve returns a copy of the CalledShot with Height set to the die
+// face that hits the called location, leaving cs unchanged
+func (cs CalledShot) resolve() (CalledShot, error) {
+
+	if cs.Location == "" {
+		if cs.Height < 1 || cs.Height > 10 {
+			return cs, fmt.Errorf("called shot height %d must be between 1 and 10", cs.Height)
+		}
+		return cs, nil
+	}
+
+	if cs.Target == nil {
+		return cs, fmt.Errorf("called shot at %s has no target", cs.Location)
+	}
+
+	for _, name := range cs.Target.LocationMap {
+		l := cs.Target.HitLocations[name]
+		if l == nil || !strings.EqualFold(name, cs.Location) {
+			continue
+		}
+		h := 0
+		for _, hl := range l.HitLoc {
+			h = Max(h, hl)
+		}
+		if h > 0 {
+			cs.Location, cs.Height = name, h
+			return cs, nil
+		}
+	}
+	return cs, fmt.Errorf("%s has no hit location %q", cs.Target.Name, cs.Location)
+}
+
+func (cs CalledShot) String() string {
+	if cs.Location == "" {
+		return fmt.Sprintf("height %d", cs.Height)
+	}
+	return fmt.Sprintf("%s's %s", cs.Target.Name, cs.Location)
 }
 
 // DiePool represents a rollable dice set in ORE
@@ -28,51 +121,59 @@ type DiePool struct {
 	Normal  int
 	Hard    int
 	Wiggle  int
-	Expert  int
+	Expert  []int // face value of each expert die
 	Spray   int
 	GoFirst int
 }
 
+// clone returns a copy of the DiePool that shares no expert faces with d
+func (d DiePool) clone() *DiePool {
+	d.Expert = append([]int(nil), d.Expert...)
+	return &d
+}
+
+// String writes the DiePool in canonical notation, e.g. 4d+2hd+1ed7+1ed9+1wd+1gf+2sp.
+// The result is read back by ParseDiePool.
 func (d DiePool) String() string {
-	var text string
 
-	if d.Normal > 0 {
-		text += fmt.Sprintf("%dd", d.Normal)
-	}
+	var terms []string
 
-	if d.Normal > 0 && d.Hard > 0 {
-		text += "+"
+	if d.Normal > 0 {
+		terms = append(terms, fmt.Sprintf("%dd", d.Normal))
 	}
 
 	if d.Hard > 0 {
-		text += fmt.Sprintf("%dhd", d.Hard)
+		terms = append(terms, fmt.Sprintf("%dhd", d.Hard))
 	}
 
-	if d.Normal > 0 && d.Expert > 0 {
-		text += "+"
+	// Group expert dice by face, lowest first
+	experts := map[int]int{}
+	for _, f := range d.Expert {
+		experts[f]++
 	}
-
-	if d.Expert > 0 {
-		text += fmt.Sprintf("%ded", 1)
-	}
-
-	if (d.Hard > 0 && d.Wiggle > 0) || (d.Normal > 0 && d.Wiggle > 0 && d.Hard == 0) {
-		text += "+"
+	for f := 1; f <= 10; f++ {
+		if experts[f] > 0 {
+			terms = append(terms, fmt.Sprintf("%ded%d", experts[f], f))
+		}
 	}
 
 	if d.Wiggle > 0 {
-		text += fmt.Sprintf("%dwd", d.Wiggle)
+		terms = append(terms, fmt.Sprintf("%dwd", d.Wiggle))
 	}
 
 	if d.GoFirst > 0 {
-		text += fmt.Sprintf(" Go First %d", d.GoFirst)
+		terms = append(terms, fmt.Sprintf("%dgf", d.GoFirst))
 	}
 
 	if d.Spray > 0 {
-		text += fmt.Sprintf(" Spray %d", d.Spray)
+		terms = append(terms, fmt.Sprintf("%dsp", d.Spray))
+	}
+
+	if len(terms) == 0 {
+		return "0d"
 	}
 
-	return text
+	return strings.Join(terms, "+")
 }
 
 // Match shows the height and width of a specific match
@@ -100,143 +201,208 @@ func (a ByWidthHeight) Less(i, j int) bool {
 	return a[i].Height > a[j].Height
 }
 
-// Resolve ORE dice roll and prints results
+// Resolve ORE dice roll and prints results. The Actor's wounds apply
+// as they do in ResolvePool
 func (r *Roll) Resolve(input string) (*Roll, error) {
 
 	r.Input = input
 
-	nd, hd, wd, ed, gf, sp, ac, _, err := r.ParseString(input)
+	spec, err := ParseDieNotation(input)
+	if err != nil {
+		r.DiePool = &DiePool{}
+		return r, err
+	}
 
-	r.NumActions = ac
+	if err := r.applyStatus(); err != nil {
+		r.DiePool = spec.DiePool.clone()
+		return r, err
+	}
 
-	r.DiePool = &DiePool{
-		Normal:  nd,
-		Hard:    hd,
-		Wiggle:  wd,
-		Expert:  ed,
-		GoFirst: gf,
-		Spray:   sp,
+	// Work on a copy so the caller's CalledShot is left as it was
+	if r.CalledShot != nil {
+		cs, err := r.CalledShot.resolve()
+		if err != nil {
+			r.DiePool = spec.DiePool.clone()
+			return r, err
+		}
+		r.CalledShot = &cs
 	}
 
-	if err != nil {
-		return r, err
+	return r.resolveSpec(spec)
+}
+
+// applyStatus sets the WoundPenalty from the Actor's Status, failing if
+// the Actor cannot act at all
+func (r *Roll) applyStatus() error {
+
+	if r.Actor == nil {
+		return nil
 	}
 
-	r.Wiggles = wd
+	st := r.Actor.Status()
+	if st.Dead || st.Unconscious {
+		return fmt.Errorf("%s cannot act: %s", r.Actor.Name, st)
+	}
+	r.WoundPenalty = st.Penalty
+	return nil
+}
 
-	actionCount := ac // Disposable counter
+// ResolvePool rolls a DiePool directly without going through die notation
+func (r *Roll) ResolvePool(d *DiePool, opts RollOptions) (*Roll, error) {
 
-	// Check for multiple actions and spray
-	// reduce die pool
-	if actionCount > 1 && sp == 0 {
-		// Remove hd first
-		for r.DiePool.Hard > 0 && actionCount > 1 {
-			r.DiePool.Hard--
-			actionCount--
-		}
-		for r.DiePool.Normal > 0 && actionCount > 1 {
-			r.DiePool.Normal--
-			actionCount--
-		}
-		for r.DiePool.Wiggle > 0 && actionCount > 1 {
-			r.DiePool.Wiggle--
-			actionCount--
-		}
+	if d == nil {
+		r.DiePool = &DiePool{}
+		return r, errors.New("no die pool to roll")
 	}
 
-	// Add spray dice to pool as normal dice
-	if r.DiePool.Spray > 0 {
-		r.DiePool.Normal += r.DiePool.Spray
+	spec := &RollSpec{
+		DiePool:  d,
+		Actions:  Max(opts.Actions, 1),
+		NumRolls: 1,
 	}
 
-	// Ensure no more than 10d in pool
-	r.verifyLessThan10() // Need to make sure 10d max after multiple actions
+	r.Input = spec.String()
+	r.Difficulty = opts.Difficulty
+	r.Penalty = opts.Penalty
+	r.Overflow = opts.Overflow
 
-	for x := 0; x < r.DiePool.Normal; x++ {
-		r.Results = append(r.Results, RollDie(10, 1, 1))
+	if !opts.IgnoreWounds {
+		if err := r.applyStatus(); err != nil {
+			r.DiePool = d.clone()
+			return r, err
+		}
 	}
 
-	for x := 0; x < r.DiePool.Hard; x++ {
-		r.Results = append(r.Results, 10)
+	if opts.Source != nil {
+		r.Source = opts.Source
 	}
 
-	if r.DiePool.Expert > 0 {
-		r.Results = append(r.Results, r.DiePool.Expert)
+	if opts.CalledShot != nil {
+		cs, err := opts.CalledShot.resolve()
+		if err != nil {
+			r.DiePool = d.clone()
+			return r, err
+		}
+		r.CalledShot = &cs
 	}
 
-	r.parseDieRoll()
+	if _, err := r.resolveSpec(spec); err != nil {
+		return r, err
+	}
 
-	// Sort roll by initiative (width+GoFirst) and then height
-	sort.Sort(ByWidthHeight(r.Matches))
+	if opts.Wiggle != nil && r.Wiggles > 0 {
+		if err := r.Wiggle(opts.Wiggle); err != nil {
+			return r, err
+		}
+	}
 
 	return r, nil
-
 }
 
-// ParseString parses string like 5d+1hd+1wd or returns error
-func (r *Roll) ParseString(input string) (int, int, int, int, int, int, int, int, error) {
+// resolveSpec rolls a parsed RollSpec
+func (r *Roll) resolveSpec(spec *RollSpec) (*Roll, error) {
 
-	re := regexp.MustCompile("[0-9]+")
+	r.NumActions = spec.Actions
 
-	var sElements []string
+	r.DiePool = spec.DiePool.clone()
 
-	errString := ""
+	reduceForActions(r.DiePool, r.NumActions, r.adjuster)
 
-	sElements = strings.SplitN(input, "+", 8)
+	// Penalty dice come off before the 10 die cap
+	removeDice(r.DiePool, r.Penalty, r.adjuster("penalty dice"))
+	removeDice(r.DiePool, r.WoundPenalty, r.adjuster("wounds"))
 
-	var nd, hd, wd, ed, gf, sp int
+	// Ensure no more than 10d in pool
+	if err := r.verifyLessThan10(); err != nil {
+		return r, err
+	}
 
-	ac, nr := 1, 1
+	if r.CalledShot != nil {
+		if err := callShot(r.DiePool, r.CalledShot.Height, r.adjuster("called shot")); err != nil {
+			return r, err
+		}
+	}
 
-	for _, s := range sElements {
-		switch {
-		case strings.Contains(s, "wd"):
-			numString := re.FindString(s)
-			wd, _ = strconv.Atoi(numString)
+	r.Wiggles = r.DiePool.Wiggle
 
-		case strings.Contains(s, "hd"):
-			numString := re.FindString(s)
-			hd, _ = strconv.Atoi(numString)
+	for x := 0; x < r.DiePool.Normal; x++ {
+		r.Results = append(r.Results, RollDieFrom(r.source(), 10, 1, 1))
+	}
 
-		case strings.Contains(s, "ed"):
-			numString := re.FindString(s)
-			ed, _ = strconv.Atoi(numString)
+	for x := 0; x < r.DiePool.Hard; x++ {
+		r.Results = append(r.Results, 10)
+	}
 
-		case strings.Contains(s, "d"):
-			numString := re.FindString(s)
-			nd, _ = strconv.Atoi(numString)
+	r.Results = append(r.Results, r.DiePool.Expert...)
 
-		case strings.Contains(s, "gf"):
-			numString := re.FindString(s)
-			gf, _ = strconv.Atoi(numString)
+	r.rematch()
 
-		case strings.Contains(s, "sp"):
-			numString := re.FindString(s)
-			sp, _ = strconv.Atoi(numString)
+	return r, nil
+}
 
-		case strings.Contains(s, "ac"):
-			numString := re.FindString(s)
-			ac, _ = strconv.Atoi(numString)
+// adjuster returns a callback that records each die removed or added for reason
+func (r *Roll) adjuster(reason string) func(dieType string, from, to int) {
+	return func(dieType string, from, to int) {
+		r.Adjustments = append(r.Adjustments, Adjustment{
+			DieType: dieType,
+			From:    from,
+			To:      to,
+			Reason:  reason,
+		})
+	}
+}
 
-		case strings.Contains(s, "nr"):
-			numString := re.FindString(s)
-			nr, _ = strconv.Atoi(numString)
+// reduceForActions removes one die for each action beyond the first,
+// hard dice first, then normal and wiggle dice. Spray attacks take no
+// penalty and instead add their Spray dice to the pool as normal dice.
+// Each change is passed to the callback built by adjust for its reason.
+func reduceForActions(d *DiePool, actions int, adjust func(reason string) func(string, int, int)) {
 
-		default:
-			errString = "Error: Not a regular die notation"
-		}
+	actionCount := actions // Disposable counter
+
+	// Check for multiple actions and spray
+	// reduce die pool
+	if actionCount > 1 && d.Spray == 0 {
+		report := adjust("multiple actions")
+		n := actionCount - 1
+
+		// Remove hd first
+		d.Hard, n = takeDice("Hard", d.Hard, n, report)
+		d.Normal, n = takeDice("Normal", d.Normal, n, report)
+		d.Wiggle, _ = takeDice("Wiggle", d.Wiggle, n, report)
+	}
+
+	// Add spray dice to pool as normal dice
+	if d.Spray > 0 {
+		d.Normal += d.Spray
+		adjust("spray")("Normal", d.Normal-d.Spray, d.Normal)
 	}
+}
+
+// ignoreAdjustments discards pool changes where nobody needs to see them
+func ignoreAdjustments(string) func(string, int, int) {
+	return func(string, int, int) {}
+}
+
+// ParseString parses string like 5d+1hd+1wd or returns error
+//
+// Deprecated: use ParseDieNotation, which returns a RollSpec
+func (r *Roll) ParseString(input string) (int, int, int, int, int, int, int, int, error) {
 
-	// Ensure at least one roll is made
-	if nr < 1 {
-		nr = 1
+	spec, err := ParseDieNotation(input)
+	if err != nil {
+		return 0, 0, 0, 0, 0, 0, 0, 0, err
 	}
 
-	if errString != "" {
-		return 0, 0, 0, 0, 0, 0, 0, 0, errors.New(errString)
+	d := spec.DiePool
+
+	// Only the first expert die's face fits the legacy return values
+	ed := 0
+	if len(d.Expert) > 0 {
+		ed = d.Expert[0]
 	}
 
-	return nd, hd, wd, ed, gf, sp, ac, nr, nil
+	return d.Normal, d.Hard, d.Wiggle, ed, d.GoFirst, d.Spray, spec.Actions, spec.NumRolls, nil
 }
 
 // Determine matches including width, height and initiative for a roll
@@ -257,85 +423,121 @@ func (r *Roll) parseDieRoll() *Roll {
 		case v == 1:
 			r.Loose = append(r.Loose, k)
 		case v > 1:
-			r.Matches = append(r.Matches, Match{
+			m := Match{
 				Actor:      r.Actor,
 				Height:     k,
 				Width:      v,
 				Initiative: v + goFirst,
-			})
+			}
+			if k < r.Difficulty {
+				r.Below = append(r.Below, m)
+			} else {
+				r.Matches = append(r.Matches, m)
+			}
 		}
 	}
 	return r
 }
 
-// VerifyLessThan10 checks and reduces die pools to less than 10d
-func (r *Roll) verifyLessThan10() {
-
-	if SumDice(r.DiePool) > 10 {
-
-		fmt.Println("Error: Can't roll more than 10 dice. Reducing to less than 10.")
-		fmt.Printf(fmt.Sprintf("Current Dice: %dd+%dhd+%dwd+default%ded.\n",
-			r.DiePool.Normal,
-			r.DiePool.Hard,
-			r.DiePool.Wiggle,
-			r.DiePool.Expert,
-		))
-
-		// Remove normal dice first
-		for r.DiePool.Normal > 0 && SumDice(r.DiePool) > 10 {
-			fmt.Printf("reduced Normal dice from %d to %d. \n",
-				r.DiePool.Normal,
-				r.DiePool.Normal-1,
-			)
-			r.DiePool.Normal--
-			fmt.Printf(fmt.Sprintf("Current Dice: %dd+%dhd+%dwd+default%ded.\n",
-				r.DiePool.Normal,
-				r.DiePool.Hard,
-				r.DiePool.Wiggle,
-				r.DiePool.Expert))
-		}
+// verifyLessThan10 applies the Roll's OverflowPolicy to pools of more than 10 dice
+func (r *Roll) verifyLessThan10() error {
 
-		// Reduce hard dice next
-		for r.DiePool.Hard > 0 && SumDice(r.DiePool) > 10 {
-			fmt.Printf("reduced Hard dice from %d to %d. \n",
-				r.DiePool.Hard,
-				r.DiePool.Hard-1,
-			)
-			r.DiePool.Hard--
-			fmt.Printf(fmt.Sprintf("Current Dice: %dd+%dhd+%dwd+default%ded.\n",
-				r.DiePool.Normal,
-				r.DiePool.Hard,
-				r.DiePool.Wiggle,
-				r.DiePool.Expert))
-		}
+	excess := SumDice(r.DiePool) - 10
+	if excess <= 0 {
+		return nil
+	}
 
-		// Reduce expert dice next
-		for r.DiePool.Expert > 0 && SumDice(r.DiePool) > 10 {
-			fmt.Printf("reduced Expert dice from %d to %d. \n",
-				1,
-				0,
-			)
-			r.DiePool.Expert = 0
-			fmt.Printf(fmt.Sprintf("Current Dice: %dd+%dhd+%dwd.\n",
-				r.DiePool.Normal,
-				r.DiePool.Hard,
-				r.DiePool.Wiggle))
-		}
+	switch r.Overflow {
+	case OverflowError:
+		return fmt.Errorf("can't roll more than 10 dice: %s is %d dice",
+			r.DiePool, SumDice(r.DiePool))
+	case OverflowBonusWidth:
+		r.BonusWidth = excess
+		capPool(r.DiePool, r.adjuster("10 die cap, converted to bonus width"))
+	default:
+		capPool(r.DiePool, r.adjuster("10 die cap"))
+	}
+	return nil
+}
 
-		// Reduce wiggle dice last
-		for r.DiePool.Wiggle > 0 && SumDice(r.DiePool) > 10 {
-			fmt.Printf("reduced Wiggle dice from %d to %d. \n",
-				r.DiePool.Wiggle,
-				r.DiePool.Wiggle-1,
-			)
-			r.DiePool.Wiggle--
-
-			fmt.Printf(fmt.Sprintf("Current Dice: %dd+%dhd+%dwd.\n",
-				r.DiePool.Normal,
-				r.DiePool.Hard,
-				r.DiePool.Wiggle))
-		}
+// capPool removes dice until no more than 10 remain.
+// report is called for each type of die removed.
+func capPool(d *DiePool, report func(dieType string, from, to int)) {
+	removeDice(d, SumDice(d)-10, report)
+}
+
+// removeDice takes n dice from the pool, normal dice first, then hard,
+// expert and finally wiggle dice. report is called once for each type of
+// die removed with the count before and after.
+func removeDice(d *DiePool, n int, report func(dieType string, from, to int)) {
+
+	// Remove normal dice first
+	d.Normal, n = takeDice("Normal", d.Normal, n, report)
+
+	// Reduce hard dice next
+	d.Hard, n = takeDice("Hard", d.Hard, n, report)
+
+	// Reduce expert dice next
+	var left int
+	left, n = takeDice("Expert", len(d.Expert), n, report)
+	d.Expert = d.Expert[:left]
+
+	// Reduce wiggle dice last
+	d.Wiggle, _ = takeDice("Wiggle", d.Wiggle, n, report)
+}
+
+// takeDice removes up to n of have dice, reporting any change, and
+// returns the dice left and how many more still need to be removed
+func takeDice(dieType string, have, n int, report func(dieType string, from, to int)) (int, int) {
+
+	taken := Min(Max(n, 0), have)
+	if taken == 0 {
+		return have, n
+	}
+
+	report(dieType, have, have-taken)
+	return have - taken, n - taken
+}
+
+// callShot sets a die to height and pays another die for the shot.
+// The die set is a normal die if there is one, then a wiggle die, then
+// an expert die; it becomes an expert die at height. Hard dice cannot
+// be set. The die paid is then removed as for penalty dice.
+func callShot(d *DiePool, height int, report func(dieType string, from, to int)) error {
+
+	if height < 1 || height > 10 {
+		return fmt.Errorf("called shot height %d must be between 1 and 10", height)
 	}
+
+	if SumDice(d) < 2 {
+		return errors.New("a called shot needs at least two dice")
+	}
+
+	switch {
+	case d.Normal > 0:
+		d.Normal--
+		report("Normal", d.Normal+1, d.Normal)
+	case d.Wiggle > 0:
+		d.Wiggle--
+		report("Wiggle", d.Wiggle+1, d.Wiggle)
+	case len(d.Expert) > 0:
+		d.Expert = d.Expert[:len(d.Expert)-1]
+		report("Expert", len(d.Expert)+1, len(d.Expert))
+	default:
+		return errors.New("a called shot cannot set a hard die")
+	}
+
+	removeDice(d, 1, report)
+
+	d.Expert = append(d.Expert, height)
+	report("Expert", len(d.Expert)-1, len(d.Expert))
+
+	return nil
+}
+
+// Succeeded reports whether any match met the Difficulty
+func (r *Roll) Succeeded() bool {
+	return len(r.Matches) > 0
 }
 
 // Provides standard string formatting for roll
@@ -344,13 +546,30 @@ func (r Roll) String() string {
 	text := ""
 	var results []Match
 
-	text += fmt.Sprintf("Actor: %s, Action: %s, Go First: %d, Spray: %d\n\n",
+	text += fmt.Sprintf("Actor: %s, Action: %s, Go First: %d, Spray: %d\n",
 		r.Actor.Name,
 		r.Action,
 		r.DiePool.GoFirst,
 		r.DiePool.Spray,
 	)
 
+	if r.Difficulty > 0 || r.Penalty > 0 {
+		text += fmt.Sprintf("Difficulty: %d, Penalty: %dd\n", r.Difficulty, r.Penalty)
+	}
+
+	if r.WoundPenalty > 0 {
+		text += fmt.Sprintf("Wound penalty: %dd\n", r.WoundPenalty)
+	}
+
+	if r.CalledShot != nil {
+		text += fmt.Sprintf("Called shot: %s (%d)\n", r.CalledShot, r.CalledShot.Height)
+	}
+
+	for _, a := range r.Adjustments {
+		text += fmt.Sprintf("Adjusted %s\n", a)
+	}
+	text += "\n"
+
 	if len(r.Matches) > 0 {
 
 		text += "Matches:\n"
@@ -374,7 +593,23 @@ func (r Roll) String() string {
 			m.Initiative, m.Height,
 		)
 	}
-	if r.Wiggles > 0 {
+
+	for _, m := range r.Below {
+		text += fmt.Sprintf("Below difficulty: %dx%d\n", m.Width, m.Height)
+	}
+
+	if r.Difficulty > 0 {
+		if r.Succeeded() {
+			text += "Success\n"
+		} else {
+			text += "Failure\n"
+		}
+	}
+
+	switch {
+	case len(r.WiggleSet) > 0:
+		text += fmt.Sprintf("Wiggle dice set to: %s\n", TrimSliceBrackets(r.WiggleSet))
+	case r.Wiggles > 0:
 		text += fmt.Sprintf("+%d wiggle dice\n", r.Wiggles)
 	}
 
@@ -394,9 +629,18 @@ func main() {
 
 	flag.Parse()
 
-	for x := 0; x < *numRolls; x++ {
-		roll := Roll{}
-		roll.Resolve(*diePool)
+	spec, err := ParseDieNotation(*diePool)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	for x := 0; x < *numRolls*spec.NumRolls; x++ {
+		roll := Roll{Actor: &Character{}, Input: *diePool}
+		if _, err := roll.resolveSpec(spec); err != nil {
+			fmt.Println(err)
+			return
+		}
 		fmt.Println(roll)
 	}
 }
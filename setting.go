@@ -1,5 +1,9 @@
 package oneroll
 
+// CostFramework holds a setting's point costs. Stat, Skill, HyperStat and
+// HyperSkill are base costs per die and Quality is added per die for each
+// Power Quality. Hard, wiggle and expert dice cost their multiple of the base.
+// StatLimit is the most dice a Statistic may have without ExceedStatLimit.
 type CostFramework struct {
 	Setting    string
 	Stat       int
@@ -10,8 +14,10 @@ type CostFramework struct {
 	HardMult   int
 	WiggleMult int
 	ExpertMult int
+	StatLimit  int
 }
 
+// Settings holds the CostFramework for each setting code
 var Settings = map[string]CostFramework{
 	"WT": CostFramework{
 		Setting:    "Wild Talents",
@@ -23,6 +29,7 @@ var Settings = map[string]CostFramework{
 		HardMult:   2,
 		WiggleMult: 4,
 		ExpertMult: 2,
+		StatLimit:  5,
 	},
 	"SR": CostFramework{
 		Setting:    "Shadowrun",
@@ -34,6 +41,7 @@ var Settings = map[string]CostFramework{
 		HardMult:   2,
 		WiggleMult: 4,
 		ExpertMult: 2,
+		StatLimit:  5,
 	},
 	"RE": CostFramework{
 		Setting:    "Reign",
@@ -42,5 +50,20 @@ var Settings = map[string]CostFramework{
 		HardMult:   2,
 		WiggleMult: 6,
 		ExpertMult: 2,
+		StatLimit:  5,
 	},
 }
+
+// PoolCost prices a DiePool at b points per normal die
+func (cf CostFramework) PoolCost(b int, d *DiePool) int {
+	return cf.explainDice(b, d).Cost
+}
+
+// CostFramework returns the costs for the Character's Setting,
+// falling back to Wild Talents
+func (c *Character) CostFramework() CostFramework {
+	if cf, ok := Settings[c.Setting]; ok {
+		return cf
+	}
+	return Settings["WT"]
+}
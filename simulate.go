@@ -0,0 +1,311 @@
+package oneroll
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Contestant is one side of a simulated contest
+type Contestant struct {
+	Actor   *Character
+	Dice    *DiePool
+	Options RollOptions
+	Damage  *DamageSpec // Damage dealt on a win, Unarmed if nil
+}
+
+// TrialResult is the outcome of a single simulated exchange
+type TrialResult struct {
+	Winner string         // Actor name, empty on a tie or if nobody matched
+	Order  []string       // Actor names in the order they act
+	Damage map[string]int // Wounds dealt by hit location name
+	Err    error          // Set if the trial could not be run
+}
+
+// Trial runs one exchange drawing every die from src
+type Trial func(src RandomSource) TrialResult
+
+// SimulationReport summarizes many Trials
+type SimulationReport struct {
+	Iterations int
+	WinRate    map[string]float64 // Share of trials won by each actor
+	NoWinner   float64            // Share of ties and trials without a match
+	MeanDamage map[string]float64 // Mean wounds per trial by hit location
+	Orders     map[string]float64 // Share of trials with each initiative order
+	Errors     int                // Trials that failed to run
+	Err        error              // First error returned by a trial
+}
+
+// Simulate runs trial iterations times from a source seeded with seed,
+// so the same seed always produces the same report. Trials that fail are
+// counted in Errors and left out of every other figure, so shares and
+// means are taken over the trials that ran.
+func Simulate(iterations int, seed int64, trial Trial) *SimulationReport {
+
+	src := NewSeededSource(seed)
+
+	rep := &SimulationReport{
+		Iterations: iterations,
+		WinRate:    map[string]float64{},
+		MeanDamage: map[string]float64{},
+		Orders:     map[string]float64{},
+	}
+
+	if iterations < 1 {
+		return rep
+	}
+
+	for i := 0; i < iterations; i++ {
+		t := trial(src)
+
+		if t.Err != nil {
+			rep.Errors++
+			if rep.Err == nil {
+				rep.Err = t.Err
+			}
+			continue
+		}
+
+		if t.Winner == "" {
+			rep.NoWinner++
+		} else {
+			rep.WinRate[t.Winner]++
+		}
+
+		for loc, d := range t.Damage {
+			rep.MeanDamage[loc] += float64(d)
+		}
+
+		rep.Orders[strings.Join(t.Order, " > ")]++
+	}
+
+	if rep.Errors == iterations {
+		return rep
+	}
+
+	n := float64(iterations - rep.Errors)
+
+	rep.NoWinner /= n
+
+	for k := range rep.WinRate {
+		rep.WinRate[k] /= n
+	}
+	for k := range rep.MeanDamage {
+		rep.MeanDamage[k] /= n
+	}
+	for k := range rep.Orders {
+		rep.Orders[k] /= n
+	}
+
+	return rep
+}
+
+// OpposedTrial rolls every Contestant against the others. The winner, as
+// decided by Oppose, strikes each other contestant with its Damage at the
+// hit location its height shows. Wounds are restored after each trial.
+func OpposedTrial(contestants ...Contestant) Trial {
+
+	return func(src RandomSource) TrialResult {
+
+		var chars []*Character
+		for _, c := range contestants {
+			chars = append(chars, c.Actor)
+		}
+		defer keepWounds(chars...)()
+
+		var rolls []*Roll
+
+		for _, c := range contestants {
+			opts := c.Options
+			opts.Source = src
+
+			r := &Roll{Actor: c.Actor}
+			if _, err := r.ResolvePool(c.Dice, opts); err != nil {
+				return TrialResult{Err: err}
+			}
+			rolls = append(rolls, r)
+		}
+
+		res := Oppose(rolls...)
+
+		t := TrialResult{
+			Order:  initiativeOrder(res.Order),
+			Damage: map[string]int{},
+		}
+
+		if res.Winner == nil {
+			return t
+		}
+
+		winner, best := res.Winner.Roll, res.Winner.Best
+
+		t.Winner = winner.Actor.Name
+
+		spec := &Unarmed
+		for _, c := range contestants {
+			if c.Actor == winner.Actor && c.Damage != nil {
+				spec = c.Damage
+			}
+		}
+
+		for _, r := range rolls {
+			if r == winner {
+				continue
+			}
+			if wr, err := r.Actor.ApplyHit(best, *spec); err == nil {
+				addWounds(t.Damage, wr)
+			}
+		}
+
+		return t
+	}
+}
+
+// SprayTrial rolls the attacker's pool and deals its matches across
+// targets with SprayAttack, widest first. The attacker wins if any match
+// wounds a target. Wounds are restored after each trial.
+func SprayTrial(attacker Contestant, targets ...*Character) Trial {
+
+	return func(src RandomSource) TrialResult {
+
+		defer keepWounds(append([]*Character{attacker.Actor}, targets...)...)()
+
+		opts := attacker.Options
+		opts.Source = src
+
+		r := &Roll{Actor: attacker.Actor}
+		if _, err := r.ResolvePool(attacker.Dice, opts); err != nil {
+			return TrialResult{Err: err}
+		}
+
+		t := TrialResult{Damage: map[string]int{}}
+
+		if len(r.Matches) == 0 {
+			return t
+		}
+		t.Order = []string{r.Actor.Name}
+
+		spec := &Unarmed
+		if attacker.Damage != nil {
+			spec = attacker.Damage
+		}
+
+		ar, err := SprayAttack(r, *spec, targets...)
+		if err != nil {
+			return TrialResult{Err: err}
+		}
+
+		var dealt int
+		for _, tr := range ar.Targets {
+			for _, wr := range tr.Hits {
+				dealt += addWounds(t.Damage, wr)
+			}
+		}
+
+		if dealt > 0 {
+			t.Winner = r.Actor.Name
+		}
+		return t
+	}
+}
+
+// initiativeOrder lists each actor once, in the order of their first Match
+func initiativeOrder(matches []Match) []string {
+
+	var order []string
+	seen := map[*Character]bool{}
+
+	for _, m := range matches {
+		if m.Actor == nil || seen[m.Actor] {
+			continue
+		}
+		seen[m.Actor] = true
+		order = append(order, m.Actor.Name)
+	}
+	return order
+}
+
+func (s SimulationReport) String() string {
+
+	text := fmt.Sprintf("Simulation of %d trials\n", s.Iterations)
+
+	if s.Errors > 0 {
+		text += fmt.Sprintf("%d trials failed: %v\n", s.Errors, s.Err)
+	}
+
+	text += "\nWin rates:\n"
+	for _, k := range sortedKeys(s.WinRate) {
+		text += fmt.Sprintf("%s: %.2f%%\n", k, s.WinRate[k]*100)
+	}
+	text += fmt.Sprintf("No winner: %.2f%%\n", s.NoWinner*100)
+
+	if len(s.MeanDamage) > 0 {
+		text += "\nMean damage:\n"
+		for _, k := range sortedKeys(s.MeanDamage) {
+			text += fmt.Sprintf("%s: %.2f\n", k, s.MeanDamage[k])
+		}
+	}
+
+	text += "\nInitiative order:\n"
+	for _, k := range sortedKeys(s.Orders) {
+		name := k
+		if name == "" {
+			name = "(no matches)"
+		}
+		text += fmt.Sprintf("%s: %.2f%%\n", name, s.Orders[k]*100)
+	}
+
+	return text
+}
+
+func sortedKeys(m map[string]float64) []string {
+
+	var keys []string
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// addWounds adds the boxes wr marked to damage by location name and
+// returns the total
+func addWounds(damage map[string]int, wr *WoundReport) int {
+
+	var total int
+
+	for _, w := range wr.Wounds {
+		n := w.Shock + w.Killing + w.Converted
+		damage[w.Location] += n
+		total += n
+	}
+	return total
+}
+
+// keepWounds records the wounds of chars and returns a func restoring them,
+// so a Trial can be run many times against the same characters
+func keepWounds(chars ...*Character) func() {
+
+	saved := map[*Location]Location{}
+
+	for _, c := range chars {
+		if c == nil {
+			continue
+		}
+		for _, l := range c.HitLocations {
+			if _, ok := saved[l]; ok {
+				continue
+			}
+			v := *l
+			v.Shock = append([]bool(nil), l.Shock...)
+			v.Kill = append([]bool(nil), l.Kill...)
+			saved[l] = v
+		}
+	}
+
+	return func() {
+		for l, v := range saved {
+			*l = v
+		}
+	}
+}
@@ -96,7 +96,7 @@ func (s *Skill) getDiePool() *DiePool {
 		td.Normal = s.Dice.Normal + s.HyperSkill.Dice.Normal
 		td.Hard = s.Dice.Hard + s.HyperSkill.Dice.Hard
 		td.Wiggle = s.Dice.Wiggle + s.HyperSkill.Dice.Wiggle
-		td.Expert = s.HyperSkill.Dice.Expert
+		td.Expert = append(append([]int(nil), s.Dice.Expert...), s.HyperSkill.Dice.Expert...)
 
 		for _, q := range s.HyperSkill.Qualities {
 			for _, m := range q.Modifiers {
@@ -115,29 +115,15 @@ func (s *Skill) getDiePool() *DiePool {
 	return td
 }
 
-// FormatDiePool returns a die string
+// FormatDiePool returns a die string in canonical notation
 func (s *Skill) FormatDiePool(actions int) string {
 
-	skill := ReturnDice(s)
-	stat := ReturnDice(s.LinkStat)
-
-	normal := stat.Normal + skill.Normal
-	hard := stat.Hard + skill.Hard
-	expert := skill.Expert
-	wiggle := stat.Wiggle + skill.Wiggle
-	goFirst := Max(stat.GoFirst, skill.GoFirst)
-	spray := Max(stat.Spray, skill.Spray)
-
-	text := fmt.Sprintf("%dac+%dd+%dhd+%dwd+%dgf+%dsp+%ded",
-		actions,
-		normal,
-		hard,
-		wiggle,
-		goFirst,
-		spray,
-		expert)
+	spec := RollSpec{
+		DiePool: CombineDice(s, s.LinkStat),
+		Actions: actions,
+	}
 
-	return text
+	return spec.String()
 }
 
 // ShowSkills shows skills grouped under stats
@@ -183,97 +169,52 @@ func ShowSkills(c *Character, allSkills bool) string {
 
 // CalculateCost determines the cost of a Skill
 // Called from Character.CalculateCharacterCost()
-func (s *Skill) CalculateCost() {
-
-	var b int
-
-	switch {
-	case s.Free:
-		b = 0
-	default:
-		b = 2
-	}
-
-	if s.Narrow {
-		b--
-	}
-
-	if s.Flexible {
-		b++
-	}
-
-	if s.Influence {
-		b++
-	}
-
-	s.CostPerDie = b
-
-	// Modifier Cost
-	mc := 0
-
-	// Add cost for HyperSkill levels applied to Stat
-	if s.HyperSkill != nil {
-		for _, q := range s.HyperSkill.Qualities {
-			if q.Level > 0 {
-				b += q.Level
-			}
-		}
-	}
+func (s *Skill) CalculateCost() int {
+	return s.CalculateCostFor(Settings["WT"])
+}
 
-	// Add costs for modifiers
-	for _, m := range s.Modifiers {
-		m.CalculateCost(0)
-		if m.RequiresLevel {
-			mc += m.CostPerLevel * m.Level
-		} else {
-			mc += m.CostPerLevel
-		}
-	}
+// CalculateCostFor stores and returns the Skill's cost using cf
+func (s *Skill) CalculateCostFor(cf CostFramework) int {
 
-	if len(s.Modifiers) > 0 && mc < 1 {
-		// There are mods, but flaws reduce cost below 1
-		mc = 1
+	s.CostPerDie = 0
+	for _, b := range s.baseItems(cf) {
+		s.CostPerDie += b.Cost
 	}
 
-	b += mc
-
-	total := b * s.Dice.Normal
-	total += b * 2 * s.Dice.Hard
-	total += b * 4 * s.Dice.Wiggle
-
-	if s.Dice.Expert > 0 {
-		total += 2
-	}
+	s.Cost = s.PriceFor(cf)
+	return s.Cost
+}
 
-	s.Cost = total
+// PriceFor returns the Skill's cost using cf without changing it
+func (s *Skill) PriceFor(cf CostFramework) int {
+	return s.ExplainCost(cf).Cost
 }
 
 // CalculateCost generates and udpates the cost for HypeSKills
-func (hs *HyperSkill) CalculateCost() {
-
-	b := 1 // base of 1, but minimum of 1 Quality with minimum cost of 1
+func (hs *HyperSkill) CalculateCost() int {
+	return hs.CalculateCostFor(Settings["WT"])
+}
 
-	for _, q := range hs.Qualities {
+// CalculateCostFor stores and returns the HyperSkill's cost using cf.
+// Qualities with more than one Capacity are priced with a Power
+// Capacity Modifier, which is never added to the Quality itself.
+func (hs *HyperSkill) CalculateCostFor(cf CostFramework) int {
 
-		// Add Power Capacity Modifier if needed
-		if len(q.Capacities) > 1 {
-			tm := Modifiers["Power Capacity"]
-			tm.Level = len(q.Capacities) - 1
-			q.Modifiers = append(q.Modifiers, &tm)
-		}
+	ci := hs.ExplainCost(cf)
+	per := ci.Items[0]
 
-		for _, m := range q.Modifiers {
-			m.CalculateCost(0)
-		}
-		q.CalculateCost(0)
-		b += q.CostPerDie
+	// Cost per die lists the base, then each Quality
+	for i, q := range hs.Qualities {
+		q.CostPerDie = per.Items[i+1].Cost
 	}
 
-	hs.CostPerDie = b
+	hs.CostPerDie = per.Cost
+	hs.Cost = ci.Cost
 
-	total := b * hs.Dice.Normal
-	total += b * 2 * hs.Dice.Hard
-	total += b * 4 * hs.Dice.Wiggle
+	return hs.Cost
+}
 
-	hs.Cost = total
+// PriceFor returns the HyperSkill's cost using cf without changing it
+func (hs *HyperSkill) PriceFor(cf CostFramework) int {
+	return hs.ExplainCost(cf).Cost
 }
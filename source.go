@@ -0,0 +1,77 @@
+package oneroll
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// RandomSource provides die faces for a Roll. Sources shared between
+// goroutines must be safe for concurrent use.
+type RandomSource interface {
+	Face(min, max int) int
+}
+
+// lockedSource is a math/rand generator guarded by a mutex
+type lockedSource struct {
+	mu sync.Mutex
+	r  *rand.Rand
+}
+
+// Face returns a value between min and max inclusive
+func (s *lockedSource) Face(min, max int) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.r.Intn(max+1-min) + min
+}
+
+// NewSeededSource returns a concurrency-safe source that always produces
+// the same sequence of faces for the same seed
+func NewSeededSource(seed int64) RandomSource {
+	return &lockedSource{r: rand.New(rand.NewSource(seed))}
+}
+
+// defaultSource is seeded once and used by any Roll without a Source
+var defaultSource = NewSeededSource(time.Now().UnixNano())
+
+// ScriptedSource returns a fixed sequence of faces, looping back to the
+// start when exhausted. Used to replay a roll or to force known results.
+type ScriptedSource struct {
+	mu    sync.Mutex
+	Faces []int
+	next  int
+}
+
+// NewScriptedSource returns a source that yields faces in order
+func NewScriptedSource(faces ...int) *ScriptedSource {
+	return &ScriptedSource{Faces: faces}
+}
+
+// Face returns the next scripted face, clamped to min and max
+func (s *ScriptedSource) Face(min, max int) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.Faces) == 0 {
+		return min
+	}
+
+	f := s.Faces[s.next%len(s.Faces)]
+	s.next++
+
+	switch {
+	case f < min:
+		f = min
+	case f > max:
+		f = max
+	}
+	return f
+}
+
+// source returns the Roll's RandomSource or the package default
+func (r *Roll) source() RandomSource {
+	if r.Source != nil {
+		return r.Source
+	}
+	return defaultSource
+}
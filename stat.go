@@ -34,6 +34,7 @@ func (s *Statistic) getDiePool() *DiePool {
 		td.Normal = s.Dice.Normal + s.HyperStat.Dice.Normal
 		td.Hard = s.Dice.Hard + s.HyperStat.Dice.Hard
 		td.Wiggle = s.Dice.Wiggle + s.HyperStat.Dice.Wiggle
+		td.Expert = append(append([]int(nil), s.Dice.Expert...), s.HyperStat.Dice.Expert...)
 
 		for _, q := range s.HyperStat.Qualities {
 			for _, m := range q.Modifiers {
@@ -52,26 +53,15 @@ func (s *Statistic) getDiePool() *DiePool {
 	return td
 }
 
-// FormatDiePool returns a die string
+// FormatDiePool returns a die string in canonical notation
 func (s *Statistic) FormatDiePool(actions int) string {
 
-	td := ReturnDice(s)
-
-	normal := td.Normal
-	hard := td.Hard
-	wiggle := td.Wiggle
-	goFirst := td.GoFirst
-	spray := td.Spray
-
-	text := fmt.Sprintf("%dac+%dd+%dhd+%dwd+%dgf+%dsp",
-		actions,
-		normal,
-		hard,
-		wiggle,
-		goFirst,
-		spray)
+	spec := RollSpec{
+		DiePool: ReturnDice(s),
+		Actions: actions,
+	}
 
-	return text
+	return spec.String()
 }
 
 func (s Statistic) String() string {
@@ -108,71 +98,46 @@ func (hs HyperStat) String() string {
 
 // CalculateCost determines the cost of a Power Quality
 // Called from Character.CalculateCharacterCost()
-func (s *Statistic) CalculateCost() {
-	// Base Cost
-	b := 5
-	// Modifier Cost
-	mc := 0
-
-	// Add costs for modifiers
-	for _, m := range s.Modifiers {
-		m.CalculateCost(0)
-		if m.RequiresLevel {
-			mc += m.CostPerLevel * m.Level
-		} else {
-			mc += m.CostPerLevel
-		}
-	}
-
-	// Add cost for HyperStat levels applied to Stat
-	if s.HyperStat != nil {
-		for _, q := range s.HyperStat.Qualities {
-			if q.Level > 0 {
-				b += q.Level
-			}
-		}
-	}
-
-	if len(s.Modifiers) > 0 && mc < 1 {
-		// There are mods, but flaws reduce cost below 1
-		mc = 1
-	}
-
-	b += mc
+func (s *Statistic) CalculateCost() int {
+	return s.CalculateCostFor(Settings["WT"])
+}
 
-	total := b * s.Dice.Normal
-	total += b * 2 * s.Dice.Hard
-	total += b * 4 * s.Dice.Wiggle
+// CalculateCostFor stores and returns the Statistic's cost using cf
+func (s *Statistic) CalculateCostFor(cf CostFramework) int {
+	s.Cost = s.PriceFor(cf)
+	return s.Cost
+}
 
-	s.Cost = total
+// PriceFor returns the Statistic's cost using cf without changing it
+func (s *Statistic) PriceFor(cf CostFramework) int {
+	return s.ExplainCost(cf).Cost
 }
 
 // CalculateCost generates and udpates the cost for HypeSKills
-func (hs *HyperStat) CalculateCost() {
-
-	b := 4 // Base Cost
+func (hs *HyperStat) CalculateCost() int {
+	return hs.CalculateCostFor(Settings["WT"])
+}
 
-	for _, q := range hs.Qualities {
+// CalculateCostFor stores and returns the HyperStat's cost using cf.
+// Qualities with more than three Capacities are priced with a Power
+// Capacity Modifier, which is never added to the Quality itself.
+func (hs *HyperStat) CalculateCostFor(cf CostFramework) int {
 
-		// Add Power Capacity Modifier if needed
-		if len(q.Capacities) > 3 {
-			tm := Modifiers["Power Capacity"]
-			tm.Level = len(q.Capacities) - 3
-			q.Modifiers = append(q.Modifiers, &tm)
-		}
+	ci := hs.ExplainCost(cf)
+	per := ci.Items[0]
 
-		for _, m := range q.Modifiers {
-			m.CalculateCost(0)
-		}
-		q.CalculateCost(0)
-		b += q.CostPerDie
+	// Cost per die lists the base, then each Quality
+	for i, q := range hs.Qualities {
+		q.CostPerDie = per.Items[i+1].Cost
 	}
 
-	hs.CostPerDie = b
+	hs.CostPerDie = per.Cost
+	hs.Cost = ci.Cost
 
-	total := b * hs.Dice.Normal
-	total += b * 2 * hs.Dice.Hard
-	total += b * 4 * hs.Dice.Wiggle
+	return hs.Cost
+}
 
-	hs.Cost = total
+// PriceFor returns the HyperStat's cost using cf without changing it
+func (hs *HyperStat) PriceFor(cf CostFramework) int {
+	return hs.ExplainCost(cf).Cost
 }
@@ -0,0 +1,93 @@
+package oneroll
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Status is a Character's condition derived from their HitLocations
+type Status struct {
+	Penalty     int      // Dice lost from every pool
+	Disabled    []string // Locations filled with wounds
+	Dazed       bool     // Shock to the Head
+	Unconscious bool     // Head or Body filled with wounds
+	Dead        bool     // Head or Body filled with Killing
+	Reasons     []string // Why each penalty die applies
+}
+
+// Status works out the Character's current penalties and condition.
+// A disabled arm or leg costs a die from every pool, as does any Shock
+// to the Head (Dazed) and any Killing to the Body. A full Head or Body
+// knocks the Character out, and filling either with Killing kills them.
+func (c *Character) Status() Status {
+
+	st := Status{}
+
+	for _, name := range c.LocationMap {
+		l := c.HitLocations[name]
+		if l == nil {
+			continue
+		}
+
+		kill, shock := l.CountWounds()
+
+		switch name {
+		case "Head":
+			if shock > 0 {
+				st.Dazed = true
+				st.Penalty++
+				st.Reasons = append(st.Reasons, "dazed by Shock to the Head")
+			}
+		case "Body":
+			if kill > 0 {
+				st.Penalty++
+				st.Reasons = append(st.Reasons, "Killing damage to the Body")
+			}
+		default:
+			if l.Disabled || l.Full() {
+				st.Penalty++
+				st.Reasons = append(st.Reasons, fmt.Sprintf("%s disabled", l.Name))
+			}
+		}
+
+		if l.Disabled || l.Full() {
+			st.Disabled = append(st.Disabled, l.Name)
+		}
+	}
+
+	st.Unconscious, st.Dead = c.vitalState()
+
+	return st
+}
+
+// Healthy reports whether the Character suffers no wound effects
+func (st Status) Healthy() bool {
+	return st.Penalty == 0 && len(st.Disabled) == 0 && !st.Unconscious && !st.Dead
+}
+
+func (st Status) String() string {
+
+	var flags []string
+
+	switch {
+	case st.Dead:
+		flags = append(flags, "Dead")
+	case st.Unconscious:
+		flags = append(flags, "Unconscious")
+	case st.Dazed:
+		flags = append(flags, "Dazed")
+	}
+
+	if len(st.Disabled) > 0 {
+		flags = append(flags, fmt.Sprintf("Disabled: %s", strings.Join(st.Disabled, ", ")))
+	}
+
+	if st.Penalty > 0 {
+		flags = append(flags, fmt.Sprintf("-%dd (%s)", st.Penalty, strings.Join(st.Reasons, ", ")))
+	}
+
+	if len(flags) == 0 {
+		return "Healthy"
+	}
+	return strings.Join(flags, "; ")
+}
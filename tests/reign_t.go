@@ -10,13 +10,8 @@ func main() {
 
 	c := oneroll.NewReignCharacter("Nornam")
 
-	r1 := oneroll.Roll{
-		Actor:  c,
-		Action: c.Skills["Athletics"].Name,
-	}
-
 	c.Skills["Athletics"].Dice.Normal = 2
-	c.Skills["Athletics"].Dice.Expert = 7
+	c.Skills["Athletics"].Dice.Expert = []int{7}
 
 	ath := c.Skills["Athletics"]
 
@@ -24,7 +19,11 @@ func main() {
 
 	fmt.Println(athString)
 
-	r1.Resolve(athString)
+	r1, err := c.RollAbility("Body+Athletics", oneroll.RollOptions{Actions: 1})
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
 
 	fmt.Println(r1)
 }
@@ -144,7 +144,7 @@ func main() {
 
 	activePower := c.Powers["Telekinisis"].Qualities[0]
 
-	ds := activePower.FormatDiePool(1)
+	ds := c.Powers["Telekinisis"].FormatQualityDiePool(activePower, 1)
 
 	actionType := fmt.Sprintf("%s %s", activePower.Type, activePower.Name)
 
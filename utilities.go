@@ -3,12 +3,10 @@ package oneroll
 import (
 	"bufio"
 	"fmt"
-	"math/rand"
 	"os"
 	"regexp"
 	"strconv"
 	"strings"
-	"time"
 )
 
 // Dice is an interface for Statistic & Skills to combine DiePool
@@ -18,7 +16,7 @@ type Dice interface {
 
 // Ability is an interface for general ORE object operations
 type Ability interface {
-	CalculateCost()
+	CalculateCost() int
 }
 
 // ReturnDice implements the Ability to combine DiePool
@@ -26,9 +24,43 @@ func ReturnDice(d Dice) *DiePool {
 	return d.getDiePool()
 }
 
+// CombineDice merges the DiePools of several Dice into a single pool.
+// Normal, hard and wiggle dice are added together, Go First and Spray
+// take the highest value and expert dice from every pool are kept.
+func CombineDice(dice ...Dice) *DiePool {
+
+	td := &DiePool{}
+
+	for _, d := range dice {
+		p := ReturnDice(d)
+		if p == nil {
+			continue
+		}
+
+		td.Normal += p.Normal
+		td.Hard += p.Hard
+		td.Wiggle += p.Wiggle
+		td.GoFirst = Max(td.GoFirst, p.GoFirst)
+		td.Spray = Max(td.Spray, p.Spray)
+		td.Expert = append(td.Expert, p.Expert...)
+	}
+	return td
+}
+
+// PricedAbility is an Ability whose cost depends on the setting
+type PricedAbility interface {
+	Ability
+	CalculateCostFor(cf CostFramework) int
+}
+
 // UpdateCost implements Ability interface to generate costs
-func UpdateCost(a Ability) {
-	a.CalculateCost()
+func UpdateCost(a Ability) int {
+	return a.CalculateCost()
+}
+
+// UpdateCostFor prices a PricedAbility with a setting's CostFramework
+func UpdateCostFor(a PricedAbility, cf CostFramework) int {
+	return a.CalculateCostFor(cf)
 }
 
 // Max returns the larger of two ints
@@ -39,16 +71,25 @@ func Max(x, y int) int {
 	return y
 }
 
-// RollDie rolls and sum dice
+// Min returns the smaller of two ints
+func Min(x, y int) int {
+	if x < y {
+		return x
+	}
+	return y
+}
+
+// RollDie rolls and sum dice using the default RandomSource
 func RollDie(max, min, numDice int) int {
+	return RollDieFrom(defaultSource, max, min, numDice)
+}
 
-	s1 := rand.NewSource(time.Now().UnixNano())
-	r1 := rand.New(s1)
+// RollDieFrom rolls and sums dice drawn from src
+func RollDieFrom(src RandomSource, max, min, numDice int) int {
 
 	result := 0
 	for i := 1; i < numDice+1; i++ {
-		roll := r1.Intn(max+1-min) + min
-		result += roll
+		result += src.Face(min, max)
 	}
 	return result
 }
@@ -78,7 +119,7 @@ func ParseNumRolls(s string) (int, error) {
 
 // SkillRated returns true if a skill has any points in it
 func SkillRated(s *Skill) bool {
-	if s.Dice.Normal+s.Dice.Hard+s.Dice.Wiggle > 0 {
+	if s.Dice.Normal+s.Dice.Hard+s.Dice.Wiggle+len(s.Dice.Expert) > 0 {
 		return true
 	}
 	return false
@@ -86,15 +127,7 @@ func SkillRated(s *Skill) bool {
 
 // SumDice sums a DiePool - used in determining BaseWill
 func SumDice(d *DiePool) int {
-
-	var r int
-
-	if d.Expert > 0 {
-		r = d.Normal + d.Hard + d.Wiggle + 1
-	} else {
-		r = d.Normal + d.Hard + d.Wiggle
-	}
-	return r
+	return d.Normal + d.Hard + d.Wiggle + len(d.Expert)
 }
 
 // UserQuery creates and question and returns the User's input as a string
@@ -0,0 +1,193 @@
+package oneroll
+
+import (
+	"fmt"
+	"sort"
+)
+
+// ProblemKind is the rule a Character breaks
+type ProblemKind string
+
+// Kinds of Problem found by Validate
+const (
+	ProblemHardDice       ProblemKind = "hard dice"
+	ProblemWiggleDice     ProblemKind = "wiggle dice"
+	ProblemStatLimit      ProblemKind = "stat limit"
+	ProblemPowerLimit     ProblemKind = "power limit"
+	ProblemHyperStat      ProblemKind = "hyperstat"
+	ProblemHyperSkill     ProblemKind = "hyperskill"
+	ProblemMiracle        ProblemKind = "miracle"
+	ProblemPoolSize       ProblemKind = "pool size"
+	ProblemSpecialization ProblemKind = "specialization"
+)
+
+// Problem is a single rule violation on a Character. Path locates the
+// offending element, such as "Skills.Athletics.HyperSkill".
+type Problem struct {
+	Kind ProblemKind
+	Path string
+	Msg  string
+}
+
+func (p Problem) Error() string {
+	return fmt.Sprintf("%s: %s", p.Path, p.Msg)
+}
+
+func (p Problem) String() string {
+	return p.Error()
+}
+
+// permissions is what a Character's Permissions allow between them
+type permissions struct {
+	hyperSkill, hyperStat, exceedStat bool
+	wiggle, hard, miracles            bool
+	powerLimit                        int // 0 for no limit
+}
+
+// allowed merges the Character's and their Archetype's Permissions.
+// Any Permission granting a rule grants it; the highest PowerLimit
+// applies unless a Permission without one allows miracles.
+func (c *Character) allowed() permissions {
+
+	var all []*Permission
+
+	for _, p := range c.Permissions {
+		all = append(all, p)
+	}
+	if c.Archetype != nil {
+		all = append(all, c.Archetype.Permissions...)
+	}
+
+	var pa permissions
+	unlimited := false
+
+	for _, p := range all {
+		pa.hyperSkill = pa.hyperSkill || p.AllowHyperSkill
+		pa.hyperStat = pa.hyperStat || p.AllowHyperStat
+		pa.exceedStat = pa.exceedStat || p.ExceedStatLimit
+		pa.wiggle = pa.wiggle || p.AllowWiggle
+		pa.hard = pa.hard || p.AllowHard
+		pa.miracles = pa.miracles || p.AllowMiracles
+
+		if p.AllowMiracles && p.PowerLimit == 0 {
+			unlimited = true
+		}
+		pa.powerLimit = Max(pa.powerLimit, p.PowerLimit)
+	}
+
+	if unlimited {
+		pa.powerLimit = 0
+	}
+	return pa
+}
+
+// Validate reports every rule the Character breaks. Archetype Permissions
+// are checked in settings that use them, which excludes Reign. Every
+// setting limits Statistics to its StatLimit, pools to 10 dice and
+// requires a Specialization for Skills that need one.
+func (c *Character) Validate() []Problem {
+
+	var problems []Problem
+
+	report := func(kind ProblemKind, path, format string, args ...interface{}) {
+		problems = append(problems, Problem{
+			Kind: kind,
+			Path: path,
+			Msg:  fmt.Sprintf(format, args...),
+		})
+	}
+
+	cf := c.CostFramework()
+	checkPermissions := c.Setting != "RE"
+	pa := c.allowed()
+
+	// checkDice reports hard and wiggle dice the Permissions don't allow
+	checkDice := func(path string, d *DiePool) {
+		if !checkPermissions || d == nil {
+			return
+		}
+		if d.Hard > 0 && !pa.hard {
+			report(ProblemHardDice, path, "%dhd without a Permission allowing hard dice", d.Hard)
+		}
+		if d.Wiggle > 0 && !pa.wiggle {
+			report(ProblemWiggleDice, path, "%dwd without a Permission allowing wiggle dice", d.Wiggle)
+		}
+	}
+
+	checkPool := func(path string, d *DiePool) {
+		if n := SumDice(d); n > 10 {
+			report(ProblemPoolSize, path, "pool of %dd is over the limit of 10", n)
+		}
+	}
+
+	for _, name := range c.statNames() {
+		s := c.Statistics[name]
+		path := "Statistics." + name
+
+		checkDice(path, s.Dice)
+
+		if n := SumDice(s.Dice); cf.StatLimit > 0 && n > cf.StatLimit && !(checkPermissions && pa.exceedStat) {
+			report(ProblemStatLimit, path, "%dd is over the limit of %dd", n, cf.StatLimit)
+		}
+
+		if s.HyperStat != nil {
+			hp := path + ".HyperStat"
+			if checkPermissions && !pa.hyperStat {
+				report(ProblemHyperStat, hp, "%s without a Permission allowing HyperStats", s.HyperStat.Name)
+			}
+			checkDice(hp, s.HyperStat.Dice)
+		}
+
+		checkPool(path, ReturnDice(s))
+	}
+
+	for _, name := range sortedSkillNames(c.Skills) {
+		s := c.Skills[name]
+		path := "Skills." + name
+
+		checkDice(path, s.Dice)
+
+		if s.ReqSpec && s.Specialization == "" {
+			report(ProblemSpecialization, path, "requires a Specialization")
+		}
+
+		if s.HyperSkill != nil {
+			hp := path + ".HyperSkill"
+			if checkPermissions && !pa.hyperSkill {
+				report(ProblemHyperSkill, hp, "%s without a Permission allowing HyperSkills", s.HyperSkill.Name)
+			}
+			checkDice(hp, s.HyperSkill.Dice)
+		}
+
+		if s.LinkStat != nil {
+			checkPool(path, CombineDice(s, s.LinkStat))
+		} else {
+			checkPool(path, ReturnDice(s))
+		}
+	}
+
+	var powers []string
+	for k := range c.Powers {
+		powers = append(powers, k)
+	}
+	sort.Strings(powers)
+
+	if checkPermissions && len(powers) > 0 {
+		switch {
+		case !pa.miracles:
+			report(ProblemMiracle, "Powers", "%d Powers without a Permission allowing miracles", len(powers))
+		case pa.powerLimit > 0 && len(powers) > pa.powerLimit:
+			report(ProblemPowerLimit, "Powers", "%d Powers exceed the limit of %d", len(powers), pa.powerLimit)
+		}
+	}
+
+	for _, name := range powers {
+		p := c.Powers[name]
+		path := "Powers." + name
+
+		checkDice(path, p.Dice)
+		checkPool(path, p.Dice)
+	}
+
+	return problems
+}
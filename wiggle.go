@@ -0,0 +1,145 @@
+package oneroll
+
+import (
+	"fmt"
+	"sort"
+)
+
+// WiggleStrategy chooses faces for n wiggle dice given the faces already rolled
+type WiggleStrategy func(results []int, n int) []int
+
+// AssignWiggles sets wiggle dice to the given faces and recalculates
+// Matches and Loose. It may be called again to try a different assignment;
+// the dice rolled before any wiggle dice were set are kept in RawResults.
+// Fewer faces than wiggle dice leaves the rest unassigned.
+func (r *Roll) AssignWiggles(faces ...int) error {
+
+	if len(faces) > r.Wiggles {
+		return fmt.Errorf("%d wiggle faces given but roll only has %d wiggle dice",
+			len(faces), r.Wiggles)
+	}
+
+	for _, f := range faces {
+		if f < 1 || f > 10 {
+			return fmt.Errorf("wiggle die face %d must be between 1 and 10", f)
+		}
+	}
+
+	if r.RawResults == nil {
+		r.RawResults = append([]int{}, r.Results...)
+	}
+
+	r.WiggleSet = append([]int{}, faces...)
+	r.Results = append(append([]int{}, r.RawResults...), faces...)
+
+	r.rematch()
+
+	return nil
+}
+
+// Wiggle assigns every wiggle die using strategy
+func (r *Roll) Wiggle(strategy WiggleStrategy) error {
+
+	raw := r.RawResults
+	if raw == nil {
+		raw = r.Results
+	}
+
+	return r.AssignWiggles(strategy(raw, r.Wiggles)...)
+}
+
+// rematch recalculates Matches, Below and Loose from Results,
+// adds any BonusWidth to the best match, up to width 10, and sorts by
+// initiative
+func (r *Roll) rematch() {
+
+	r.Matches = nil
+	r.Loose = nil
+	r.Below = nil
+
+	r.parseDieRoll()
+
+	if r.BonusWidth > 0 && len(r.Matches) > 0 {
+		best := 0
+		for i, m := range r.Matches {
+			b := r.Matches[best]
+			if m.Width > b.Width || (m.Width == b.Width && m.Height > b.Height) {
+				best = i
+			}
+		}
+		// Widths beyond 10 are counted as 10, as in Odds
+		w := Min(r.Matches[best].Width+r.BonusWidth, 10)
+		r.Matches[best].Initiative += w - r.Matches[best].Width
+		r.Matches[best].Width = w
+	}
+
+	// Sort roll by initiative (width+GoFirst) and then height
+	sort.Sort(ByWidthHeight(r.Matches))
+}
+
+// WiggleForWidth adds every wiggle die to the most common face,
+// preferring the higher face on a tie
+func WiggleForWidth(results []int, n int) []int {
+
+	counts := map[int]int{}
+	for _, d := range results {
+		counts[d]++
+	}
+
+	best := 10
+	for face := 10; face > 0; face-- {
+		if counts[face] > counts[best] {
+			best = face
+		}
+	}
+
+	return wiggleFill(best, n)
+}
+
+// WiggleForHeight makes the highest match possible, then widens it
+func WiggleForHeight(results []int, n int) []int {
+
+	counts := map[int]int{}
+	high := 0
+	for _, d := range results {
+		counts[d]++
+		if d > high {
+			high = d
+		}
+	}
+
+	target := 10
+	if n == 1 && counts[10] == 0 && high > 0 {
+		// A single wiggle die can only pair with a die already rolled
+		target = high
+	}
+
+	return wiggleFill(target, n)
+}
+
+// WiggleForTarget sets every wiggle die to height, e.g. to hit a chosen location
+func WiggleForTarget(height int) WiggleStrategy {
+	return func(results []int, n int) []int {
+		return wiggleFill(height, n)
+	}
+}
+
+// WiggleForLocation sets every wiggle die to the highest height that hits l
+func WiggleForLocation(l *Location) WiggleStrategy {
+
+	height := 0
+	for _, h := range l.HitLoc {
+		height = Max(height, h)
+	}
+
+	return WiggleForTarget(height)
+}
+
+func wiggleFill(face, n int) []int {
+
+	faces := make([]int, n)
+	for i := range faces {
+		faces[i] = face
+	}
+	return faces
+}